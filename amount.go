@@ -116,8 +116,13 @@ func NewAmount[T any](value T, code string, opts ...Option) (*Amount, error) {
 		}
 	}
 
+	// Amounts are always finite and have no signed zero
+	if err := checkFinite(amount); err != nil {
+		return nil, err
+	}
+
 	// Round to currency's decimal places
-	amount = amount.Round(info.DecimalPlaces, RoundHalfEven)
+	amount = unsignedZero(amount.Round(info.DecimalPlaces, RoundHalfEven))
 
 	return &Amount{
 		amount: amount,
@@ -396,6 +401,11 @@ func (c *Amount) Code() string {
 	return c.info.Code
 }
 
+// NumericCode returns the ISO 4217 numeric currency code.
+func (c *Amount) NumericCode() string {
+	return c.info.NumericCode
+}
+
 // Symbol returns the currency symbol.
 func (c *Amount) Symbol() string {
 	return c.info.Symbol
@@ -418,58 +428,51 @@ func (c *Amount) String() string {
 
 // Format formats the currency with various options.
 func Format(c *Amount, includeSymbol, includeCode bool) string {
-	var sb strings.Builder
+	var buf [48]byte
+	return string(c.AppendFormat(buf[:0], includeSymbol, includeCode))
+}
 
-	// Handle negative amounts
-	isNegative := c.amount.IsNegative()
-	absAmount := c.amount.Abs()
+// Format formats the currency with various options.
+func (c *Amount) Format(includeSymbol, includeCode bool) string {
+	return Format(c, includeSymbol, includeCode)
+}
 
-	if isNegative {
-		sb.WriteByte('-')
+// AppendFormat appends the amount formatted like Format to dst and
+// returns the extended buffer. The zero value of Amount, e.g. of an
+// unset flag, appends nothing.
+func (c *Amount) AppendFormat(dst []byte, includeSymbol, includeCode bool) []byte {
+	if c.amount == nil {
+		return dst
 	}
-
-	// Add symbol if requested
-	if includeSymbol {
-		sb.WriteString(c.info.Symbol)
+	if c.amount.IsNegative() {
+		dst = append(dst, '-')
 	}
-
-	// Format the amount
-	amountStr := absAmount.String()
-
-	// Handle currencies with no decimal places
-	if c.info.DecimalPlaces == 0 {
-		// Remove decimal point if present
-		if idx := strings.Index(amountStr, "."); idx >= 0 {
-			amountStr = amountStr[:idx]
-		}
-	} else {
-		// Ensure correct decimal places
-		if idx := strings.Index(amountStr, "."); idx >= 0 {
-			decimalPart := amountStr[idx+1:]
-			if len(decimalPart) < c.info.DecimalPlaces {
-				// Pad with zeros
-				amountStr += strings.Repeat("0", c.info.DecimalPlaces-len(decimalPart))
-			}
-		} else {
-			// No decimal point, add it
-			amountStr += "." + strings.Repeat("0", c.info.DecimalPlaces)
+	if includeSymbol {
+		dst = append(dst, c.info.Symbol...)
+	}
+
+	// Append the absolute amount with the decimal places of the currency,
+	// currencies without decimal places drop the fraction
+	switch {
+	case !c.amount.IsFinite():
+		dst = append(dst, c.amount.Abs().specialString()...)
+	case c.info.DecimalPlaces == 0 && c.amount.scale > 0:
+		digits := []uint8{0}
+		if c.amount.scale < len(c.amount.digits) {
+			digits = c.amount.digits[c.amount.scale:]
 		}
+		dst = appendDigits(dst, digits, 0, 0)
+	case isZero(c.amount.digits):
+		dst = appendDigits(dst, []uint8{0}, 0, c.info.DecimalPlaces)
+	default:
+		dst = appendDigits(dst, c.amount.digits, c.amount.scale, c.info.DecimalPlaces)
 	}
 
-	sb.WriteString(amountStr)
-
-	// Add code if requested
 	if includeCode {
-		sb.WriteByte(' ')
-		sb.WriteString(c.info.Code)
+		dst = append(dst, ' ')
+		dst = append(dst, c.info.Code...)
 	}
-
-	return sb.String()
-}
-
-// Format formats the currency with various options.
-func (c *Amount) Format(includeSymbol, includeCode bool) string {
-	return Format(c, includeSymbol, includeCode)
+	return dst
 }
 
 // FormatWithSeparators formats the currency with custom separators.
@@ -583,16 +586,31 @@ func (c *Amount) Sub(other *Amount) (*Amount, error) {
 	}, nil
 }
 
-// Mul multiplies currency by a BCD factor.
+// Mul multiplies currency by a finite BCD factor. Amounts are always
+// finite, so an infinite or NaN factor returns the zero value of Amount,
+// which is no valid amount. Use MulChecked for such factors.
 func (c *Amount) Mul(factor *BCD) *Amount {
+	result, err := c.MulChecked(factor)
+	if err != nil {
+		return &Amount{}
+	}
+	return result
+}
+
+// MulChecked multiplies currency by a BCD factor like Mul. Infinite and
+// NaN factors return ErrInvalidAmount.
+func (c *Amount) MulChecked(factor *BCD) (*Amount, error) {
+	if err := checkFinite(factor); err != nil {
+		return nil, err
+	}
 	result := c.amount.Mul(factor)
 	// Round to currency's decimal places
-	result = result.Round(c.info.DecimalPlaces, RoundHalfEven)
+	result = unsignedZero(result.Round(c.info.DecimalPlaces, RoundHalfEven))
 
 	return &Amount{
 		amount: result,
 		info:   c.info,
-	}
+	}, nil
 }
 
 // MulInt64 multiplies the currency by an integer.
@@ -600,17 +618,22 @@ func (c *Amount) MulInt64(n int64) *Amount {
 	return c.Mul(fromInt64(n))
 }
 
-// MulFloat64 multiplies currency by a float.
+// MulFloat64 multiplies currency by a float. Infinities and NaNs
+// return ErrInvalidAmount.
 func (c *Amount) MulFloat64(f float64) (*Amount, error) {
 	factor, err := New(f, WithScale(4)) // Use 4 decimal places for factors
 	if err != nil {
 		return nil, err
 	}
-	return c.Mul(factor), nil
+	return c.MulChecked(factor)
 }
 
-// Div divides currency by a BCD divisor.
+// Div divides currency by a BCD divisor. Infinite and NaN divisors
+// return ErrInvalidAmount.
 func (c *Amount) Div(divisor *BCD) (*Amount, error) {
+	if err := checkFinite(divisor); err != nil {
+		return nil, err
+	}
 	if divisor.IsZero() {
 		return nil, ErrDivisionByZero
 	}
@@ -622,7 +645,7 @@ func (c *Amount) Div(divisor *BCD) (*Amount, error) {
 	}
 
 	// Round to currency's decimal places
-	result = result.Round(c.info.DecimalPlaces, RoundHalfEven)
+	result = unsignedZero(result.Round(c.info.DecimalPlaces, RoundHalfEven))
 
 	return &Amount{
 		amount: result,
@@ -635,7 +658,8 @@ func (c *Amount) DivInt64(n int64) (*Amount, error) {
 	return c.Div(fromInt64(n))
 }
 
-// DivFloat64 divides currency by a float.
+// DivFloat64 divides currency by a float. Infinities and NaNs return
+// ErrInvalidAmount.
 func (c *Amount) DivFloat64(f float64) (*Amount, error) {
 	divisor, err := New(f, WithScale(4))
 	if err != nil {
@@ -791,12 +815,31 @@ func (c *Amount) Equal(other *Amount) bool {
 	return c.amount.Equal(other.amount)
 }
 
+// checkFinite returns ErrInvalidAmount if the value is infinite or a NaN.
+func checkFinite(b *BCD) error {
+	if !b.IsFinite() {
+		return fmt.Errorf("%w: %s is not finite", ErrInvalidAmount, b)
+	}
+	return nil
+}
+
 // GetCurrencyInfo returns the CurrencyInfo for the given code.
 func GetCurrencyInfo(code string) (CurrencyInfo, bool) {
 	info, ok := currencyData[strings.ToUpper(code)]
 	return info, ok
 }
 
+// GetCurrencyInfoByNumericCode returns the CurrencyInfo for the given
+// ISO 4217 numeric code, e.g. "978" for EUR.
+func GetCurrencyInfoByNumericCode(numericCode string) (CurrencyInfo, bool) {
+	for _, info := range currencyData {
+		if info.NumericCode == numericCode {
+			return info, true
+		}
+	}
+	return CurrencyInfo{}, false
+}
+
 // SupportedCurrencies returns a list of all supported currency codes.
 func SupportedCurrencies() []string {
 	codes := make([]string, 0, len(currencyData))
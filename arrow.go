@@ -0,0 +1,263 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Byte widths and maximum precisions of the Arrow decimal types.
+const (
+	arrowDecimal128Width     = 16
+	arrowDecimal128Precision = 38
+	arrowDecimal256Width     = 32
+	arrowDecimal256Precision = 76
+)
+
+// chunkDigits is the number of decimal digits processed at once, the
+// largest power of ten fitting into an uint64 is 10^19.
+const chunkDigits = 19
+
+// powersOfTen contains 10^0 up to 10^19.
+var powersOfTen = func() [chunkDigits + 1]uint64 {
+	var p [chunkDigits + 1]uint64
+	p[0] = 1
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+// ArrowDecimal contains a column in the layout of the Arrow decimal128
+// or decimal256 types without depending on the Arrow library. Data has
+// ByteWidth bytes per value, each an unscaled little-endian two's
+// complement integer. Validity is the bitmap of the non-null values,
+// least significant bit first, and may be nil if there are no nulls.
+type ArrowDecimal struct {
+	Precision int
+	Scale     int
+	ByteWidth int
+	Length    int
+	NullCount int
+	Data      []byte
+	Validity  []byte
+}
+
+// NewArrowDecimal128 creates a decimal128 column with up to 38 digits
+// of the values. They are rounded to the scale with the given mode, nil
+// values are nulls. Values exceeding the precision return ErrOverflow,
+// infinities and NaNs ErrInvalidOperation.
+func NewArrowDecimal128(values []*BCD, precision, scale int, mode RoundingMode) (*ArrowDecimal, error) {
+	return newArrowDecimal(values, precision, scale, mode, arrowDecimal128Width, arrowDecimal128Precision)
+}
+
+// NewArrowDecimal256 creates a decimal256 column with up to 76 digits
+// of the values, see NewArrowDecimal128.
+func NewArrowDecimal256(values []*BCD, precision, scale int, mode RoundingMode) (*ArrowDecimal, error) {
+	return newArrowDecimal(values, precision, scale, mode, arrowDecimal256Width, arrowDecimal256Precision)
+}
+
+// newArrowDecimal creates a column of the given width.
+func newArrowDecimal(values []*BCD, precision, scale int, mode RoundingMode, width, maxPrecision int) (*ArrowDecimal, error) {
+	if precision > maxPrecision {
+		return nil, fmt.Errorf("%w: precision %d exceeds %d", ErrInvalidOperation, precision, maxPrecision)
+	}
+	a := &ArrowDecimal{
+		Precision: precision,
+		Scale:     scale,
+		ByteWidth: width,
+		Length:    len(values),
+		Data:      make([]byte, len(values)*width),
+	}
+	for i, v := range values {
+		if v == nil {
+			if a.Validity == nil {
+				a.Validity = make([]byte, (len(values)+7)/8)
+				for j := range i {
+					a.Validity[j/8] |= 1 << (j % 8)
+				}
+			}
+			a.NullCount++
+			continue
+		}
+		if a.Validity != nil {
+			a.Validity[i/8] |= 1 << (i % 8)
+		}
+		r, shift, err := v.rescale(precision, scale, mode)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		var w wideInt
+		w.setDigits(r.digits, shift)
+		if r.negative {
+			w.negate()
+		}
+		w.put(a.Data[i*width : (i+1)*width])
+	}
+	return a, nil
+}
+
+// IsValid returns true if the value at index i is not null.
+func (a *ArrowDecimal) IsValid(i int) bool {
+	return a.Validity == nil || a.Validity[i/8]&(1<<(i%8)) != 0
+}
+
+// Values returns the values of the column, nil for nulls.
+func (a *ArrowDecimal) Values() ([]*BCD, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	values := make([]*BCD, a.Length)
+	for i := range values {
+		if a.IsValid(i) {
+			values[i] = a.value(i)
+		}
+	}
+	return values, nil
+}
+
+// Value returns the value at index i and false if it is null.
+func (a *ArrowDecimal) Value(i int) (*BCD, bool, error) {
+	if err := a.validate(); err != nil {
+		return nil, false, err
+	}
+	if i < 0 || i >= a.Length {
+		return nil, false, fmt.Errorf("%w: index %d out of range", ErrInvalidOperation, i)
+	}
+	if !a.IsValid(i) {
+		return nil, false, nil
+	}
+	return a.value(i), true, nil
+}
+
+// validate checks the sizes of the buffers.
+func (a *ArrowDecimal) validate() error {
+	switch {
+	case a.ByteWidth != arrowDecimal128Width && a.ByteWidth != arrowDecimal256Width:
+		return fmt.Errorf("%w: invalid Arrow decimal byte width %d", ErrInvalidFormat, a.ByteWidth)
+	case a.Length < 0 || len(a.Data) < a.Length*a.ByteWidth:
+		return fmt.Errorf("%w: Arrow decimal data too short", ErrInvalidFormat)
+	case a.Validity != nil && len(a.Validity) < (a.Length+7)/8:
+		return fmt.Errorf("%w: Arrow validity bitmap too short", ErrInvalidFormat)
+	case a.Scale < 0:
+		return fmt.Errorf("%w: negative scale %d", ErrInvalidOperation, a.Scale)
+	}
+	return nil
+}
+
+// value decodes the value at index i.
+func (a *ArrowDecimal) value(i int) *BCD {
+	var w wideInt
+	w.get(a.Data[i*a.ByteWidth : (i+1)*a.ByteWidth])
+	negative := w.isNegative()
+	if negative {
+		w.negate()
+	}
+	return fromUnscaledDigits(w.digits(), negative, a.Scale)
+}
+
+// wideInt is a two's complement integer of 256 bits as little-endian
+// 64 bit words. Narrower buffers are read sign-extended.
+type wideInt struct {
+	words [4]uint64
+}
+
+// setDigits sets the integer to the little-endian digits followed by
+// shift zeros.
+func (w *wideInt) setDigits(digits []uint8, shift int) {
+	// Process the digits from the most significant in chunks
+	i := significantLength(digits)
+	for i > 0 {
+		k := min(i, chunkDigits)
+		var chunk uint64
+		for j := i - 1; j >= i-k; j-- {
+			chunk = chunk*10 + uint64(digits[j])
+		}
+		w.mulAdd(powersOfTen[k], chunk)
+		i -= k
+	}
+	for shift > 0 {
+		k := min(shift, chunkDigits)
+		w.mulAdd(powersOfTen[k], 0)
+		shift -= k
+	}
+}
+
+// mulAdd sets the integer to w * m + a.
+func (w *wideInt) mulAdd(m, a uint64) {
+	carry := a
+	for i := range w.words {
+		hi, lo := bits.Mul64(w.words[i], m)
+		var c uint64
+		w.words[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+}
+
+// negate negates the integer in two's complement over all words.
+func (w *wideInt) negate() {
+	carry := uint64(1)
+	for i := range w.words {
+		w.words[i], carry = bits.Add64(^w.words[i], 0, carry)
+	}
+}
+
+// put writes the integer little-endian into the buffer, its length
+// defines the number of words.
+func (w *wideInt) put(buf []byte) {
+	for i := range len(buf) / 8 {
+		binary.LittleEndian.PutUint64(buf[i*8:], w.words[i])
+	}
+}
+
+// get reads the integer little-endian from the buffer and sign-extends
+// it to all words.
+func (w *wideInt) get(buf []byte) {
+	n := len(buf) / 8
+	for i := range n {
+		w.words[i] = binary.LittleEndian.Uint64(buf[i*8:])
+	}
+	if w.words[n-1]>>63 != 0 {
+		for i := n; i < len(w.words); i++ {
+			w.words[i] = ^uint64(0)
+		}
+	}
+}
+
+// isNegative returns true if the sign bit is set.
+func (w *wideInt) isNegative() bool {
+	return w.words[len(w.words)-1]>>63 != 0
+}
+
+// digits returns the little-endian decimal digits of the non-negative
+// integer.
+func (w *wideInt) digits() []uint8 {
+	var digits []uint8
+	for !w.isZero() {
+		// Divide by 10^19 and emit the remainder as digits
+		var rem uint64
+		for i := len(w.words) - 1; i >= 0; i-- {
+			w.words[i], rem = bits.Div64(rem, w.words[i], powersOfTen[chunkDigits])
+		}
+		for range chunkDigits {
+			digits = append(digits, uint8(rem%10))
+			rem /= 10
+		}
+	}
+	if len(digits) == 0 {
+		return []uint8{0}
+	}
+	return digits
+}
+
+// isZero returns true if all words are zero.
+func (w *wideInt) isZero() bool {
+	return w.words == [4]uint64{}
+}
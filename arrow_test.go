@@ -0,0 +1,173 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestArrowDecimal128(t *testing.T) {
+	values := []*BCD{Must("1.5"), nil, Must("-1"), Must("0"), nil, Must("1.005")}
+	a, err := NewArrowDecimal128(values, 10, 2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, a.Length, 6)
+	verify.Equal(t, a.NullCount, 2)
+	verify.Equal(t, a.ByteWidth, 16)
+	verify.Equal(t, len(a.Data), 96)
+	verify.Equal(t, hex.EncodeToString(a.Validity), "2d")
+
+	// Unscaled 150, null, -100, 0, null, 100
+	verify.Equal(t, hex.EncodeToString(a.Data[0:16]), "96000000000000000000000000000000")
+	verify.Equal(t, hex.EncodeToString(a.Data[16:32]), "00000000000000000000000000000000")
+	verify.Equal(t, hex.EncodeToString(a.Data[32:48]), "9cffffffffffffffffffffffffffffff")
+	verify.Equal(t, hex.EncodeToString(a.Data[80:96]), "64000000000000000000000000000000")
+
+	back, err := a.Values()
+	verify.NoError(t, err)
+	expected := []string{"1.50", "", "-1.00", "0", "", "1.00"}
+	for i, v := range back {
+		if expected[i] == "" {
+			verify.True(t, v == nil, strconv.Itoa(i))
+			continue
+		}
+		verify.Equal(t, v.String(), expected[i], strconv.Itoa(i))
+	}
+
+	v, ok, err := a.Value(1)
+	verify.NoError(t, err)
+	verify.True(t, !ok)
+	verify.True(t, v == nil)
+	v, ok, err = a.Value(2)
+	verify.NoError(t, err)
+	verify.True(t, ok)
+	verify.Equal(t, v.String(), "-1.00")
+	_, _, err = a.Value(6)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestArrowDecimalNoNulls(t *testing.T) {
+	a, err := NewArrowDecimal256([]*BCD{Must("1"), Must("2")}, 5, 0, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, a.ByteWidth, 32)
+	verify.Equal(t, a.NullCount, 0)
+	verify.True(t, a.Validity == nil)
+	verify.True(t, a.IsValid(1))
+
+	// The bitmap is added late and has to mark the earlier values.
+	values := make([]*BCD, 20)
+	for i := range 19 {
+		values[i] = Must(strconv.Itoa(i))
+	}
+	a, err = NewArrowDecimal128(values, 5, 0, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(a.Validity), "ffff07")
+	verify.Equal(t, a.NullCount, 1)
+}
+
+func TestArrowDecimalMatchesUnscaled(t *testing.T) {
+	tests := []struct {
+		value     string
+		precision int
+		scale     int
+	}{
+		{"99999999999999999999999999999999999999", 38, 0},
+		{"-99999999999999999999999999999999999999", 38, 0},
+		{"-1234.5678", 20, 4},
+		{"12345678901234567890.123456789", 38, 9},
+		{"9999999999999999999999999999999999999999999999999999999999999999999999999999", 76, 0},
+		{"-9999999999999999999999999999999999999999999999999999999999999999999999999999", 76, 0},
+		{"-0.000000000000000000000000000000000000000000000000000000000000000000000000001", 76, 75},
+	}
+	for _, test := range tests {
+		value := Must(test.value)
+		create, width := NewArrowDecimal128, 16
+		if test.precision > 38 {
+			create, width = NewArrowDecimal256, 32
+		}
+		a, err := create([]*BCD{value}, test.precision, test.scale, RoundHalfEven)
+		verify.NoError(t, err)
+
+		// Arrow is the little-endian form of the fixed unscaled bytes.
+		expected, err := value.ToUnscaledBytes(test.precision, test.scale, width, RoundHalfEven)
+		verify.NoError(t, err)
+		slices.Reverse(expected)
+		verify.Equal(t, hex.EncodeToString(a.Data), hex.EncodeToString(expected), test.value)
+
+		back, err := a.Values()
+		verify.NoError(t, err)
+		verify.True(t, back[0].Equal(value), test.value)
+	}
+}
+
+func TestArrowDecimalErrors(t *testing.T) {
+	_, err := NewArrowDecimal128([]*BCD{Must("1")}, 39, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = NewArrowDecimal256([]*BCD{Must("1")}, 77, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = NewArrowDecimal128([]*BCD{Must("1000")}, 5, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = NewArrowDecimal128([]*BCD{Must("1"), Inf(1)}, 5, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	_, err = (&ArrowDecimal{ByteWidth: 8, Length: 1, Data: make([]byte, 8)}).Values()
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = (&ArrowDecimal{ByteWidth: 16, Length: 2, Data: make([]byte, 16)}).Values()
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = (&ArrowDecimal{ByteWidth: 16, Length: 9, Data: make([]byte, 144), Validity: []byte{0xff}}).Values()
+	verify.IsError(t, err, ErrInvalidFormat)
+}
+
+// arrowRows creates a million values with scale 2 and every
+// hundredth value null.
+func arrowRows() []*BCD {
+	rows := make([]*BCD, 1_000_000)
+	for i := range rows {
+		if i%100 == 99 {
+			continue
+		}
+		rows[i] = Must(strconv.Itoa(i*7919%10000000-5000000) + ".25")
+	}
+	return rows
+}
+
+func BenchmarkArrowDecimal128Encode(b *testing.B) {
+	rows := arrowRows()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = NewArrowDecimal128(rows, 18, 2, RoundHalfEven)
+	}
+}
+
+func BenchmarkArrowDecimal128Decode(b *testing.B) {
+	a, _ := NewArrowDecimal128(arrowRows(), 18, 2, RoundHalfEven)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = a.Values()
+	}
+}
+
+func BenchmarkArrowDecimal256Encode(b *testing.B) {
+	rows := arrowRows()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = NewArrowDecimal256(rows, 40, 4, RoundHalfEven)
+	}
+}
+
+func BenchmarkArrowDecimal256Decode(b *testing.B) {
+	a, _ := NewArrowDecimal256(arrowRows(), 40, 4, RoundHalfEven)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = a.Values()
+	}
+}
@@ -11,6 +11,7 @@
 package bcd
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"strconv"
@@ -19,10 +20,11 @@ import (
 
 // Common errors returned by the package.
 var (
-	ErrDivisionByZero = fmt.Errorf("division by zero")
-	ErrInvalidFormat  = fmt.Errorf("invalid decimal format")
-	ErrOverflow       = fmt.Errorf("numeric overflow")
-	ErrPrecisionLoss  = fmt.Errorf("precision loss")
+	ErrDivisionByZero   = fmt.Errorf("division by zero")
+	ErrInvalidFormat    = fmt.Errorf("invalid decimal format")
+	ErrOverflow         = fmt.Errorf("numeric overflow")
+	ErrPrecisionLoss    = fmt.Errorf("precision loss")
+	ErrInvalidOperation = fmt.Errorf("invalid operation")
 )
 
 // RoundingMode defines how to round decimal numbers.
@@ -55,6 +57,8 @@ type BCD struct {
 	scale int
 	// negative indicates if the number is negative.
 	negative bool
+	// form marks infinities and NaNs, the digits are unused then.
+	form form
 }
 
 // Numeric represents types that can be converted to BCD.
@@ -86,7 +90,8 @@ func WithRounding(mode RoundingMode) Option {
 	}
 }
 
-// New creates a BCD from any numeric type.
+// New creates a BCD from any numeric type. Float NaNs and infinities
+// return the corresponding special values.
 func New[T Numeric](value T, opts ...Option) (*BCD, error) {
 	options := &options{
 		scale:        6, // default scale for floats
@@ -158,6 +163,11 @@ func parseString(s string) (*BCD, error) {
 		return Zero(), nil
 	}
 
+	// Handle NaN and infinities
+	if special, ok := parseSpecial(s); ok {
+		return special, nil
+	}
+
 	// Handle scientific notation
 	if strings.ContainsAny(s, "eE") {
 		f, err := strconv.ParseFloat(s, 64)
@@ -234,8 +244,11 @@ func parseString(s string) (*BCD, error) {
 		}
 	}
 
-	// Create digit array (little-endian)
+	// Create digit array (little-endian), keeping the sign of zero
 	if allDigits == "" || allDigits == "0" {
+		if negative {
+			return NegZero(), nil
+		}
 		return Zero(), nil
 	}
 
@@ -252,7 +265,7 @@ func parseString(s string) (*BCD, error) {
 	return &BCD{
 		digits:   digits,
 		scale:    scale,
-		negative: negative && !isZero(digits),
+		negative: negative,
 	}, nil
 }
 
@@ -262,15 +275,17 @@ func fromInt64(n int64) *BCD {
 		return Zero()
 	}
 
+	// The magnitude as uint64 also covers math.MinInt64
 	negative := n < 0
+	magnitude := uint64(n)
 	if negative {
-		n = -n
+		magnitude = -magnitude
 	}
 
 	var digits []uint8
-	for n > 0 {
-		digits = append(digits, uint8(n%10))
-		n /= 10
+	for magnitude > 0 {
+		digits = append(digits, uint8(magnitude%10))
+		magnitude /= 10
 	}
 
 	return &BCD{
@@ -280,16 +295,30 @@ func fromInt64(n int64) *BCD {
 	}
 }
 
-// fromFloat64 creates a BCD from a float64 with specified scale.
+// fromFloat64 creates a BCD from a float64 with specified scale. NaN
+// and the infinities map to their special values.
 func fromFloat64(f float64, scale int) (*BCD, error) {
-	if math.IsNaN(f) || math.IsInf(f, 0) {
-		return nil, ErrInvalidFormat
+	switch {
+	case math.IsNaN(f):
+		return NaN(), nil
+	case math.IsInf(f, 0):
+		return Inf(int(math.Copysign(1, f))), nil
 	}
 
 	// Convert to string with proper precision
 	format := fmt.Sprintf("%%.%df", scale)
 	s := fmt.Sprintf(format, f)
-	return parseString(s)
+	b, err := parseString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a real negative zero keeps its sign, not a small negative
+	// number rounded to zero
+	if b.IsZero() && f != 0 {
+		b.negative = false
+	}
+	return b, nil
 }
 
 // Copy creates a deep copy of the BCD.
@@ -300,74 +329,83 @@ func (b *BCD) Copy() *BCD {
 		digits:   digits,
 		scale:    b.scale,
 		negative: b.negative,
+		form:     b.form,
 	}
 }
 
-// String returns the string representation of the BCD.
+// String returns the string representation of the BCD. Special values
+// are returned as "NaN", "sNaN", "Inf", "-Inf", and "-0".
 func (b *BCD) String() string {
-	if len(b.digits) == 0 || (len(b.digits) == 1 && b.digits[0] == 0) {
-		return "0"
-	}
-
-	// Convert digits to string (remember they're in little-endian)
-	var sb strings.Builder
+	var buf [40]byte
+	return string(b.appendString(buf[:0], 0))
+}
 
-	// Pre-allocate capacity
-	capacity := len(b.digits) + 1 // digits + possible decimal point
-	if b.negative {
-		capacity++
+// appendString appends the string representation of the BCD to dst,
+// padding the fraction with zeros to at least minScale decimal places.
+func (b *BCD) appendString(dst []byte, minScale int) []byte {
+	if b.form != finite {
+		return append(dst, b.specialString()...)
 	}
-	sb.Grow(capacity)
-
 	if b.negative {
-		sb.WriteByte('-')
+		dst = append(dst, '-')
 	}
+	digits, scale := b.digits, b.scale
+	if isZero(digits) {
+		digits, scale = []uint8{0}, 0
+	}
+	return appendDigits(dst, digits, scale, minScale)
+}
 
-	// Determine where to place the decimal point
-	intDigits := len(b.digits) - b.scale
-
+// appendDigits appends the little-endian digits with the decimal point
+// before the scale last digits and pads the fraction to minScale places.
+func appendDigits(dst []byte, digits []uint8, scale, minScale int) []byte {
+	intDigits := len(digits) - scale
 	if intDigits <= 0 {
-		// Number is less than 1
-		sb.WriteString("0.")
-		// Add leading zeros
+		// Number is less than 1, add leading zeros
+		dst = append(dst, '0', '.')
 		for range -intDigits {
-			sb.WriteByte('0')
+			dst = append(dst, '0')
 		}
-		// Add the digits
-		for i := len(b.digits) - 1; i >= 0; i-- {
-			sb.WriteByte(b.digits[i] + '0')
+		for i := len(digits) - 1; i >= 0; i-- {
+			dst = append(dst, digits[i]+'0')
 		}
 	} else {
-		// Add integer part
-		for i := len(b.digits) - 1; i >= b.scale; i-- {
-			sb.WriteByte(b.digits[i] + '0')
+		for i := len(digits) - 1; i >= scale; i-- {
+			dst = append(dst, digits[i]+'0')
 		}
-
-		if b.scale > 0 {
-			// Add decimal point and fractional part
-			sb.WriteByte('.')
-			for i := b.scale - 1; i >= 0; i-- {
-				sb.WriteByte(b.digits[i] + '0')
+		if scale > 0 {
+			dst = append(dst, '.')
+			for i := scale - 1; i >= 0; i-- {
+				dst = append(dst, digits[i]+'0')
 			}
 		}
 	}
-
-	return sb.String()
+	if minScale > scale {
+		if scale == 0 {
+			dst = append(dst, '.')
+		}
+		for range minScale - scale {
+			dst = append(dst, '0')
+		}
+	}
+	return dst
 }
 
-// IsZero returns true if the BCD is zero.
+// IsZero returns true if the BCD is zero, regardless of its sign.
 func (b *BCD) IsZero() bool {
-	return isZero(b.digits)
+	return b.form == finite && isZero(b.digits)
 }
 
-// IsNegative returns true if the BCD is negative.
+// IsNegative returns true if the BCD is negative. Negative zero and
+// NaNs are not negative.
 func (b *BCD) IsNegative() bool {
-	return b.negative && !b.IsZero()
+	return b.negative && !b.IsZero() && !b.IsNaN()
 }
 
-// IsPositive returns true if the BCD is positive.
+// IsPositive returns true if the BCD is positive. Zero and NaNs are
+// not positive.
 func (b *BCD) IsPositive() bool {
-	return !b.negative && !b.IsZero()
+	return !b.negative && !b.IsZero() && !b.IsNaN()
 }
 
 // Scale returns the scale (number of decimal places) of the BCD.
@@ -376,7 +414,12 @@ func (b *BCD) Scale() int {
 }
 
 // Precision returns the total number of significant digits.
+// Special values have no precision.
 func (b *BCD) Precision() int {
+	if b.form != finite {
+		return 0
+	}
+
 	// Remove trailing zeros from fractional part for precision calculation
 	digits := b.digits
 	scale := b.scale
@@ -396,9 +439,10 @@ func (b *BCD) Abs() *BCD {
 	return c
 }
 
-// Neg returns the negation of the BCD.
+// Neg returns the negation of the BCD. Zero stays zero, a negative zero
+// becomes zero.
 func (b *BCD) Neg() *BCD {
-	if b.IsZero() {
+	if b.IsZero() && !b.negative {
 		return b.Copy()
 	}
 	c := b.Copy()
@@ -406,9 +450,13 @@ func (b *BCD) Neg() *BCD {
 	return c
 }
 
-// Normalize removes trailing zeros after the decimal point.
+// Normalize removes trailing zeros after the decimal point. A signaling
+// NaN becomes a quiet NaN.
 func (b *BCD) Normalize() *BCD {
-	if b.scale == 0 || b.IsZero() {
+	if nan := propagateNaN(b); nan != nil {
+		return nan
+	}
+	if b.scale == 0 || b.IsZero() || b.form != finite {
 		return b.Copy()
 	}
 
@@ -442,12 +490,31 @@ func (b *BCD) Normalize() *BCD {
 //	-1 if b < other
 //	 0 if b == other
 //	 1 if b > other
+//
+// Negative zero equals zero. Like cmp.Compare a NaN is considered less
+// than any other value and equal to another NaN, so Cmp can be used for
+// sorting.
 func (b *BCD) Cmp(other *BCD) int {
+	// Handle special values
+	if b.IsNaN() || other.IsNaN() {
+		switch {
+		case b.IsNaN() && other.IsNaN():
+			return 0
+		case b.IsNaN():
+			return -1
+		default:
+			return 1
+		}
+	}
+	if b.form == infinite || other.form == infinite {
+		return cmp.Compare(infinityRank(b), infinityRank(other))
+	}
+
 	// Handle signs
-	if b.negative && !other.negative {
+	if b.IsNegative() && !other.IsNegative() {
 		return -1
 	}
-	if !b.negative && other.negative {
+	if !b.IsNegative() && other.IsNegative() {
 		return 1
 	}
 
@@ -455,39 +522,53 @@ func (b *BCD) Cmp(other *BCD) int {
 	result := compareMagnitudes(b, other)
 
 	// If both negative, reverse the result
-	if b.negative {
+	if b.IsNegative() {
 		return -result
 	}
 	return result
 }
 
-// Equal returns true if b equals other.
+// Equal returns true if b equals other. A NaN equals nothing.
 func (b *BCD) Equal(other *BCD) bool {
-	return b.Cmp(other) == 0
+	return !b.IsNaN() && !other.IsNaN() && b.Cmp(other) == 0
 }
 
-// LessThan returns true if b < other.
+// LessThan returns true if b < other. A NaN is unordered.
 func (b *BCD) LessThan(other *BCD) bool {
-	return b.Cmp(other) < 0
+	return !b.IsNaN() && !other.IsNaN() && b.Cmp(other) < 0
 }
 
-// LessOrEqual returns true if b <= other.
+// LessOrEqual returns true if b <= other. A NaN is unordered.
 func (b *BCD) LessOrEqual(other *BCD) bool {
-	return b.Cmp(other) <= 0
+	return !b.IsNaN() && !other.IsNaN() && b.Cmp(other) <= 0
 }
 
-// GreaterThan returns true if b > other.
+// GreaterThan returns true if b > other. A NaN is unordered.
 func (b *BCD) GreaterThan(other *BCD) bool {
-	return b.Cmp(other) > 0
+	return !b.IsNaN() && !other.IsNaN() && b.Cmp(other) > 0
 }
 
-// GreaterOrEqual returns true if b >= other.
+// GreaterOrEqual returns true if b >= other. A NaN is unordered.
 func (b *BCD) GreaterOrEqual(other *BCD) bool {
-	return b.Cmp(other) >= 0
+	return !b.IsNaN() && !other.IsNaN() && b.Cmp(other) >= 0
 }
 
-// Add returns b + other.
+// Add returns b + other. Inf + -Inf is NaN.
 func (b *BCD) Add(other *BCD) *BCD {
+	// Handle special values
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan
+	}
+	if b.form == infinite || other.form == infinite {
+		if b.form == infinite && other.form == infinite && b.negative != other.negative {
+			return NaN()
+		}
+		if b.form == infinite {
+			return b.Copy()
+		}
+		return other.Copy()
+	}
+
 	// Handle same sign
 	if b.negative == other.negative {
 		aligned1, aligned2 := alignDecimals(b, other)
@@ -516,14 +597,37 @@ func (b *BCD) Add(other *BCD) *BCD {
 	}
 }
 
-// Sub returns b - other.
+// Sub returns b - other. Inf - Inf is NaN.
 func (b *BCD) Sub(other *BCD) *BCD {
-	return b.Add(other.Neg())
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan
+	}
+
+	// Flip the sign of zeros too, so that -0 - 0 stays -0
+	negated := other.Copy()
+	negated.negative = !negated.negative
+	return b.Add(negated)
 }
 
-// Mul returns b * other.
+// Mul returns b * other. Inf * 0 is NaN.
 func (b *BCD) Mul(other *BCD) *BCD {
+	// Handle special values
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan
+	}
+	negative := b.negative != other.negative
+	if b.form == infinite || other.form == infinite {
+		if b.IsZero() || other.IsZero() {
+			return NaN()
+		}
+		return Inf(signOf(negative))
+	}
+
 	if b.IsZero() || other.IsZero() {
+		// Only a negative zero operand produces a signed zero
+		if (b.isNegZero() || other.isNegZero()) && negative {
+			return NegZero()
+		}
 		return Zero()
 	}
 
@@ -555,27 +659,71 @@ func (b *BCD) Mul(other *BCD) *BCD {
 }
 
 // Div returns b / other with the specified scale and rounding mode.
+// A finite value divided by zero returns ErrDivisionByZero, use Quo
+// for IEEE 754 behavior. Special values propagate: a NaN operand and
+// Inf / Inf return NaN, a signaling NaN returns ErrInvalidOperation.
 func (b *BCD) Div(other *BCD, scale int, mode RoundingMode) (*BCD, error) {
+	if err := checkSignaling(b, other); err != nil {
+		return nil, err
+	}
+	if special := divideSpecial(b, other); special != nil {
+		return special, nil
+	}
+
 	if other.IsZero() {
 		return nil, ErrDivisionByZero
 	}
 
 	if b.IsZero() {
+		if b.isNegZero() && !other.negative {
+			return NegZero(), nil
+		}
 		return Zero(), nil
 	}
 
 	// Perform division with extra precision for rounding
-	quotient, _ := divideWithRemainder(b, other, scale+1)
+	quotient, remainder := divideWithRemainder(b, other, scale+1)
 
-	// Apply rounding
+	// Apply rounding, a non-zero remainder counts for the rounding
 	quotient.negative = b.negative != other.negative
-	quotient = quotient.Round(scale, mode)
+	quotient = quotient.round(scale, mode, !remainder.IsZero())
 
 	return quotient, nil
 }
 
-// DivInt returns the integer quotient b / other.
+// Quo returns b / other with the specified scale and rounding mode
+// following IEEE 754: a non-zero value divided by zero is a signed
+// infinity, 0 / 0 is NaN. A signaling NaN becomes a quiet NaN.
+func (b *BCD) Quo(other *BCD, scale int, mode RoundingMode) *BCD {
+	if special := divideSpecial(b, other); special != nil {
+		return special
+	}
+	if other.IsZero() {
+		if b.IsZero() {
+			return NaN()
+		}
+		return Inf(signOf(b.negative != other.negative))
+	}
+	quotient, _ := b.Div(other, scale, mode)
+	return quotient
+}
+
+// DivInt returns the integer quotient b / other. Infinite dividends
+// return NaN, infinite divisors zero.
 func (b *BCD) DivInt(other *BCD) (*BCD, error) {
+	if err := checkSignaling(b, other); err != nil {
+		return nil, err
+	}
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan, nil
+	}
+	if b.form == infinite {
+		return NaN(), nil
+	}
+	if other.form == infinite {
+		return Zero(), nil
+	}
+
 	if other.IsZero() {
 		return nil, ErrDivisionByZero
 	}
@@ -585,7 +733,7 @@ func (b *BCD) DivInt(other *BCD) (*BCD, error) {
 	}
 
 	quotient, _ := divideWithRemainder(b, other, 0)
-	quotient.negative = b.negative != other.negative
+	quotient.negative = b.negative != other.negative && !quotient.IsZero()
 
 	// Truncate to integer
 	if quotient.scale > 0 {
@@ -600,8 +748,22 @@ func (b *BCD) DivInt(other *BCD) (*BCD, error) {
 	return quotient, nil
 }
 
-// Mod returns b % other.
+// Mod returns b % other. An infinite dividend returns NaN, an infinite
+// divisor returns b.
 func (b *BCD) Mod(other *BCD) (*BCD, error) {
+	if err := checkSignaling(b, other); err != nil {
+		return nil, err
+	}
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan, nil
+	}
+	if b.form == infinite {
+		return NaN(), nil
+	}
+	if other.form == infinite {
+		return b.Copy(), nil
+	}
+
 	if other.IsZero() {
 		return nil, ErrDivisionByZero
 	}
@@ -610,62 +772,126 @@ func (b *BCD) Mod(other *BCD) (*BCD, error) {
 		return Zero(), nil
 	}
 
-	_, remainder := divideWithRemainder(b, other, 0)
-	remainder.negative = b.negative
+	// Align both operands to a common scale and divide them as integers,
+	// the remainder then has that scale too
+	x, y := alignDecimals(b.Abs(), other.Abs())
+	scale := x.scale
+	x.scale, y.scale = 0, 0
+	_, remainder := divideIntegers(x, y)
+	remainder.scale = scale
+	remainder.negative = b.negative && !remainder.IsZero()
 
 	return remainder, nil
 }
 
-// Round rounds the BCD to the specified number of decimal places using the given mode.
+// Round rounds the BCD to the specified number of decimal places using
+// the given mode. Infinities are returned unchanged, a signaling NaN
+// becomes a quiet NaN.
 func (b *BCD) Round(places int, mode RoundingMode) *BCD {
+	if nan := propagateNaN(b); nan != nil {
+		return nan
+	}
+	if b.form != finite {
+		return b.Copy()
+	}
+	return b.round(places, mode, false)
+}
+
+// Sqrt returns the square root of b with the specified scale and rounding mode.
+// The result is correctly rounded, no floating-point approximation is used.
+// The root of +Inf is +Inf, the root of -0 is -0.
+func (b *BCD) Sqrt(scale int, mode RoundingMode) (*BCD, error) {
+	if err := checkSignaling(b); err != nil {
+		return nil, err
+	}
+	if nan := propagateNaN(b); nan != nil {
+		return nan, nil
+	}
+	if b.IsNegative() {
+		return nil, fmt.Errorf("%w: square root of negative number", ErrInvalidOperation)
+	}
+	if b.form == infinite {
+		return Inf(1), nil
+	}
+	if scale < 0 {
+		scale = 0
+	}
+
+	if b.IsZero() {
+		return b.Copy(), nil
+	}
+
+	// Shift the value to an integer with 2*(scale+1) decimal places,
+	// so that the integer root has scale+1 decimal places. Digits
+	// dropped on the way are remembered for the final rounding.
+	sticky := false
+	shift := 2*(scale+1) - b.scale
+	radicand := &BCD{digits: b.digits}
+	if shift > 0 {
+		zeros := make([]uint8, shift)
+		radicand.digits = append(zeros, b.digits...)
+	} else if shift < 0 {
+		if -shift >= len(b.digits) {
+			sticky = true
+			radicand.digits = []uint8{0}
+		} else {
+			sticky = !isZero(b.digits[:-shift])
+			radicand.digits = b.digits[-shift:]
+		}
+	}
+
+	root := integerSqrt(radicand)
+	if !sticky {
+		sticky = compareMagnitudes(root.Mul(root), radicand) != 0
+	}
+	root.scale = scale + 1
+
+	return root.round(scale, mode, sticky), nil
+}
+
+// round rounds the BCD to the specified number of decimal places. The sticky
+// flag signals that non-zero digits beyond the stored digits have already
+// been discarded, e.g. the remainder of a division.
+func (b *BCD) round(places int, mode RoundingMode, sticky bool) *BCD {
 	if places < 0 {
 		places = 0
 	}
 
 	// Already at desired precision
-	if b.scale <= places {
+	if b.scale <= places && !sticky {
 		return b.Copy()
 	}
 
-	// Calculate how many digits to remove
-	removeCount := b.scale - places
-	if removeCount >= len(b.digits) {
-		// All digits would be removed
-		if b.IsZero() {
-			return Zero()
-		}
-		// Round 0.00...xyz to 0 or ±1
-		if shouldRoundUp(b.digits[len(b.digits)-1], 0, false, mode, b.negative) {
-			result := &BCD{
-				digits:   []uint8{1},
-				scale:    places,
-				negative: b.negative,
-			}
-			if places == 0 {
-				result.scale = 0
-			} else {
-				// Need to add zeros
-				zeros := make([]uint8, places-1)
-				result.digits = append(zeros, 1)
-			}
-			return result
-		}
-		return Zero()
+	// Ensure there is a digit to round on
+	digits := b.digits
+	scale := b.scale
+	if scale <= places {
+		zeros := make([]uint8, places+1-scale)
+		digits = append(zeros, digits...)
+		scale = places + 1
 	}
 
-	// Copy digits we're keeping
-	newDigits := make([]uint8, len(b.digits)-removeCount)
-	copy(newDigits, b.digits[removeCount:])
+	// Determine the rounding digit and if anything non-zero follows it
+	removeCount := scale - places
+	var roundDigit uint8
+	if removeCount-1 < len(digits) {
+		roundDigit = digits[removeCount-1]
+	}
+	if !sticky {
+		sticky = !isZero(digits[:min(removeCount-1, len(digits))])
+	}
 
-	// Check if we need to round up
-	roundDigit := b.digits[removeCount-1]
-	var nextDigit uint8
-	if removeCount >= 2 {
-		nextDigit = b.digits[removeCount-2]
+	// Copy digits we're keeping
+	var newDigits []uint8
+	if removeCount < len(digits) {
+		newDigits = make([]uint8, len(digits)-removeCount)
+		copy(newDigits, digits[removeCount:])
+	} else {
+		newDigits = []uint8{0}
 	}
 	isEven := newDigits[0]%2 == 0
 
-	if shouldRoundUp(roundDigit, nextDigit, isEven, mode, b.negative) {
+	if shouldRoundUp(roundDigit, sticky, isEven, mode, b.negative) {
 		// Add 1 to the result
 		carry := uint8(1)
 		for i := 0; i < len(newDigits) && carry > 0; i++ {
@@ -678,11 +904,13 @@ func (b *BCD) Round(places int, mode RoundingMode) *BCD {
 		}
 	}
 
-	// Remove trailing zeros if scale becomes 0
-	if places == 0 {
-		for len(newDigits) > 1 && newDigits[0] == 0 {
-			newDigits = newDigits[1:]
-		}
+	// Remove leading zeros
+	for len(newDigits) > 1 && newDigits[len(newDigits)-1] == 0 {
+		newDigits = newDigits[:len(newDigits)-1]
+	}
+
+	if isZero(newDigits) {
+		return Zero()
 	}
 
 	return &BCD{
@@ -693,7 +921,15 @@ func (b *BCD) Round(places int, mode RoundingMode) *BCD {
 }
 
 // ToInt64 converts the BCD to int64, returning an error if the value doesn't fit.
+// Infinities return ErrOverflow, NaNs ErrInvalidOperation.
 func (b *BCD) ToInt64() (int64, error) {
+	if b.IsNaN() {
+		return 0, ErrInvalidOperation
+	}
+	if b.form == infinite {
+		return 0, ErrOverflow
+	}
+
 	// Truncate to integer (round towards zero)
 	rounded := b.Round(0, RoundDown)
 
@@ -733,14 +969,59 @@ func (b *BCD) ToInt64() (int64, error) {
 	return result, nil
 }
 
-// ToFloat64 converts the BCD to float64.
+// ToFloat64 converts the BCD to float64. Special values are converted
+// to their float64 counterparts.
 func (b *BCD) ToFloat64() float64 {
+	if b.IsNaN() {
+		return math.NaN()
+	}
 	f, _ := strconv.ParseFloat(b.String(), 64)
 	return f
 }
 
 // Helper functions
 
+// signOf returns -1 for negative, 1 otherwise.
+func signOf(negative bool) int {
+	if negative {
+		return -1
+	}
+	return 1
+}
+
+// infinityRank orders -Inf, finite values, and +Inf.
+func infinityRank(b *BCD) int {
+	switch {
+	case b.IsInf(-1):
+		return -1
+	case b.IsInf(1):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// divideSpecial returns the result of a division with a NaN or infinite
+// operand, or nil if both are finite.
+func divideSpecial(b, other *BCD) *BCD {
+	if nan := propagateNaN(b, other); nan != nil {
+		return nan
+	}
+	negative := b.negative != other.negative
+	switch {
+	case b.form == infinite && other.form == infinite:
+		return NaN()
+	case b.form == infinite:
+		return Inf(signOf(negative))
+	case other.form == infinite:
+		if negative {
+			return NegZero()
+		}
+		return Zero()
+	}
+	return nil
+}
+
 // isZero checks if all digits are zero.
 func isZero(digits []uint8) bool {
 	for _, d := range digits {
@@ -756,16 +1037,19 @@ func compareMagnitudes(a, b *BCD) int {
 	// Align decimals for comparison
 	aligned1, aligned2 := alignDecimals(a, b)
 
-	// Compare lengths
-	if len(aligned1.digits) > len(aligned2.digits) {
+	// Compare lengths without leading zeros, a zero integer
+	// part may be stored as digit or not
+	len1 := significantLength(aligned1.digits)
+	len2 := significantLength(aligned2.digits)
+	if len1 > len2 {
 		return 1
 	}
-	if len(aligned1.digits) < len(aligned2.digits) {
+	if len1 < len2 {
 		return -1
 	}
 
 	// Same length, compare digits from most significant
-	for i := len(aligned1.digits) - 1; i >= 0; i-- {
+	for i := len1 - 1; i >= 0; i-- {
 		if aligned1.digits[i] > aligned2.digits[i] {
 			return 1
 		}
@@ -777,6 +1061,15 @@ func compareMagnitudes(a, b *BCD) int {
 	return 0
 }
 
+// significantLength returns the number of digits without leading zeros.
+func significantLength(digits []uint8) int {
+	n := len(digits)
+	for n > 0 && digits[n-1] == 0 {
+		n--
+	}
+	return n
+}
+
 // alignDecimals aligns two BCDs to have the same scale.
 func alignDecimals(a, b *BCD) (*BCD, *BCD) {
 	if a.scale == b.scale {
@@ -867,21 +1160,22 @@ func subtractMagnitudes(a, b *BCD) *BCD {
 }
 
 // shouldRoundUp determines if rounding should increase the magnitude.
-func shouldRoundUp(digit, nextDigit uint8, isEven bool, mode RoundingMode, negative bool) bool {
+// The sticky flag reports if any non-zero digit follows the rounding digit.
+func shouldRoundUp(digit uint8, sticky, isEven bool, mode RoundingMode, negative bool) bool {
 	switch mode {
 	case RoundDown:
 		return false
 	case RoundUp:
-		return digit > 0 || nextDigit > 0
+		return digit > 0 || sticky
 	case RoundHalfUp:
 		return digit >= 5
 	case RoundHalfDown:
-		return digit > 5 || (digit == 5 && nextDigit > 0)
+		return digit > 5 || (digit == 5 && sticky)
 	case RoundHalfEven:
-		if digit > 5 || (digit == 5 && nextDigit > 0) {
+		if digit > 5 || (digit == 5 && sticky) {
 			return true
 		}
-		if digit == 5 && nextDigit == 0 {
+		if digit == 5 && !sticky {
 			return !isEven
 		}
 		return false
@@ -889,12 +1183,12 @@ func shouldRoundUp(digit, nextDigit uint8, isEven bool, mode RoundingMode, negat
 		if negative {
 			return false
 		}
-		return digit > 0 || nextDigit > 0
+		return digit > 0 || sticky
 	case RoundFloor:
 		if !negative {
 			return false
 		}
-		return digit > 0 || nextDigit > 0
+		return digit > 0 || sticky
 	default:
 		return false
 	}
@@ -1029,41 +1323,56 @@ func longDivision(dividend, divisor *BCD) (*BCD, *BCD) {
 		}
 	}
 
-	// Fallback to digit-by-digit division for large numbers
-	// This is still slow but at least correct
-	quotientDigits := make([]uint8, 0)
-	remainder := dividend.Copy()
+	// Fallback to schoolbook long division for large numbers, each
+	// quotient digit is found by repeated subtraction.
+	quotientDigits := make([]uint8, len(dividend.digits))
+	remainder := Zero()
 
-	// Estimate quotient by repeated subtraction with multipliers
-	for compareMagnitudes(remainder, divisor) >= 0 {
-		// Find the largest multiplier where divisor * multiplier <= remainder
-		multiplier := uint8(1)
-		testProduct := divisor.Copy()
-
-		for multiplier < 9 {
-			nextProduct := multiplyByDigit(divisor, multiplier+1)
-			if compareMagnitudes(nextProduct, remainder) > 0 {
-				break
-			}
-			multiplier++
-			testProduct = nextProduct
+	for i := len(dividend.digits) - 1; i >= 0; i-- {
+		// Bring down the next digit
+		if isZero(remainder.digits) {
+			remainder.digits = []uint8{dividend.digits[i]}
+		} else {
+			remainder.digits = append([]uint8{dividend.digits[i]}, remainder.digits...)
 		}
 
-		// Subtract divisor * multiplier from remainder
-		remainder = subtractMagnitudes(remainder, testProduct)
-		quotientDigits = append([]uint8{multiplier}, quotientDigits...)
+		q := uint8(0)
+		for compareMagnitudes(remainder, divisor) >= 0 {
+			remainder = subtractMagnitudes(remainder, divisor)
+			q++
+		}
+		quotientDigits[i] = q
 	}
 
-	// Reverse quotient digits
-	for i, j := 0, len(quotientDigits)-1; i < j; i, j = i+1, j-1 {
-		quotientDigits[i], quotientDigits[j] = quotientDigits[j], quotientDigits[i]
+	// Remove leading zeros
+	for len(quotientDigits) > 1 && quotientDigits[len(quotientDigits)-1] == 0 {
+		quotientDigits = quotientDigits[:len(quotientDigits)-1]
 	}
 
-	if len(quotientDigits) == 0 {
-		quotientDigits = []uint8{0}
+	return &BCD{digits: quotientDigits}, remainder
+}
+
+// integerSqrt returns the floor of the square root of a non-negative
+// BCD integer using Newton's iteration.
+func integerSqrt(n *BCD) *BCD {
+	if isZero(n.digits) {
+		return Zero()
 	}
 
-	return &BCD{digits: quotientDigits}, remainder
+	// Start with a power of ten above the root
+	start := make([]uint8, (len(n.digits)+1)/2+1)
+	start[len(start)-1] = 1
+	x := &BCD{digits: start}
+	two := fromInt64(2)
+
+	for {
+		q, _ := divideIntegers(n, x)
+		y, _ := divideIntegers(addMagnitudes(x, q), two)
+		if compareMagnitudes(y, x) >= 0 {
+			return x
+		}
+		x = y
+	}
 }
 
 // multiplyByDigit multiplies a BCD by a single digit
@@ -64,6 +64,7 @@ func TestNewBCD_Generic(t *testing.T) {
 			{"int16", int16(32767), "32767"},
 			{"int32", int32(-2147483648), "-2147483648"},
 			{"int64", int64(9223372036854775807), "9223372036854775807"},
+			{"min int64", int64(-9223372036854775808), "-9223372036854775808"},
 			{"uint", uint(123), "123"},
 			{"uint8", uint8(255), "255"},
 			{"uint16", uint16(65535), "65535"},
@@ -135,12 +136,14 @@ func TestNewBCD_Generic(t *testing.T) {
 		}
 	})
 
-	t.Run("error cases", func(t *testing.T) {
-		_, err := New(math.NaN())
-		verify.ErrorMatch(t, err, ".*")
+	t.Run("special values", func(t *testing.T) {
+		b, err := New(math.NaN())
+		verify.NoError(t, err)
+		verify.True(t, b.IsNaN())
 
-		_, err = New(math.Inf(1))
-		verify.ErrorMatch(t, err, ".*")
+		b, err = New(math.Inf(1))
+		verify.NoError(t, err)
+		verify.True(t, b.IsInf(1))
 	})
 }
 
@@ -190,6 +193,13 @@ func TestBCDArithmetic(t *testing.T) {
 		{"divide with decimal", "10", "4", "/", "2.5"},
 		{"divide decimals", "7.5", "2.5", "/", "3"},
 		{"divide by one", "123.45", "1", "/", "123.45"},
+
+		// Modulo
+		{"modulo integers", "100", "7", "%", "2"},
+		{"modulo decimal divisor", "10", "3.5", "%", "3"},
+		{"modulo decimal dividend", "10.5", "3", "%", "1.5"},
+		{"modulo negative dividend", "-7.25", "2", "%", "-1.25"},
+		{"modulo negative divisor", "7", "-2", "%", "1"},
 	}
 
 	for _, tt := range tests {
@@ -213,6 +223,10 @@ func TestBCDArithmetic(t *testing.T) {
 				verify.NoError(t, err)
 				// Simplify result for comparison
 				result = result.Round(2, RoundHalfUp).Normalize()
+			case "%":
+				result, err = a.Mod(b)
+				verify.NoError(t, err)
+				result = result.Normalize()
 			}
 
 			verify.Equal(t, result.String(), tt.want)
@@ -236,6 +250,8 @@ func TestBCDComparison(t *testing.T) {
 		{"positive greater than zero", "5", "0", 1},
 		{"decimal comparison", "123.45", "123.46", -1},
 		{"different scales equal", "123.4500", "123.45", 0},
+		{"zero less than fraction", "0", "0.5", -1},
+		{"fraction greater than zero", "0.05", "0", 1},
 	}
 
 	for _, tt := range tests {
@@ -299,6 +315,17 @@ func TestBCDRounding(t *testing.T) {
 		// RoundFloor
 		{"floor positive", "1.29", 1, RoundFloor, "1.2"},
 		{"floor negative", "-1.21", 1, RoundFloor, "-1.3"},
+
+		// Rounding to integers keeps trailing zeros of the integer part
+		{"integer down", "10.5", 0, RoundDown, "10"},
+		{"integer half up", "100.5", 0, RoundHalfUp, "101"},
+		{"integer half even", "0.5", 0, RoundHalfEven, "0"},
+
+		// All digits behind the rounding position count
+		{"half even above tie", "1.2501", 1, RoundHalfEven, "1.3"},
+		{"half down above tie", "1.2501", 1, RoundHalfDown, "1.3"},
+		{"small up", "0.001", 2, RoundUp, "0.01"},
+		{"small half up", "0.005", 1, RoundHalfUp, "0"},
 	}
 
 	for _, tt := range tests {
@@ -310,6 +337,58 @@ func TestBCDRounding(t *testing.T) {
 	}
 }
 
+func TestBCDDivision(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{"remainder rounds up", "1", "300", 1, RoundUp, "0.1"},
+		{"remainder breaks tie", "0.1251", "1", 2, RoundHalfEven, "0.13"},
+		{"exact tie", "0.125", "1", 2, RoundHalfEven, "0.12"},
+		{"large operands", "98765432109876543210", "12345678901234567890", 20, RoundHalfEven, "8.00000007290000066339"},
+		{"large divisor", "1", "12345678901234567890", 30, RoundHalfEven, "0.000000000000000000081000000729"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Must(tt.a).Div(Must(tt.b), tt.scale, tt.mode)
+			verify.NoError(t, err)
+			verify.Equal(t, got.String(), tt.want)
+		})
+	}
+}
+
+func TestBCDSqrt(t *testing.T) {
+	tests := []struct {
+		value string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{"0", 4, RoundHalfEven, "0"},
+		{"4", 2, RoundHalfEven, "2.00"},
+		{"2", 10, RoundHalfEven, "1.4142135624"},
+		{"2", 10, RoundDown, "1.4142135623"},
+		{"0.0001", 3, RoundHalfEven, "0.010"},
+		{"0.25", 0, RoundHalfEven, "0"},
+		{"0.25", 0, RoundHalfUp, "1"},
+		{"123456789012345678901234567890", 4, RoundHalfEven, "351364182882014.4253"},
+	}
+
+	for _, tt := range tests {
+		got, err := Must(tt.value).Sqrt(tt.scale, tt.mode)
+		verify.NoError(t, err)
+		verify.Equal(t, got.String(), tt.want)
+	}
+
+	_, err := Must("-1").Sqrt(2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
 func TestBCDConversion(t *testing.T) {
 	t.Run("ToInt64", func(t *testing.T) {
 		tests := []struct {
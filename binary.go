@@ -0,0 +1,224 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Sign nibbles of packed decimals like in COBOL COMP-3.
+const (
+	packedPositive = 0xC
+	packedNegative = 0xD
+)
+
+// binaryNegative marks negative special values in the header byte.
+const binaryNegative = 0x80
+
+// AppendBinary implements encoding.BinaryAppender. The value is written
+// as header byte with the form of the value, for finite values followed
+// by the scale as uvarint and the packed decimal digits, two per byte,
+// most significant first, and the sign nibble 0xC or 0xD at the end. The
+// header of negative infinities and NaNs has the bit 0x80 set.
+func (b *BCD) AppendBinary(dst []byte) ([]byte, error) {
+	if b.form != finite {
+		header := byte(b.form)
+		if b.negative {
+			header |= binaryNegative
+		}
+		return append(dst, header), nil
+	}
+	dst = append(dst, byte(finite))
+	dst = binary.AppendUvarint(dst, uint64(b.scale))
+
+	// Collect the significant digits, most significant first
+	n := max(significantLength(b.digits), 1)
+	sign := byte(packedPositive)
+	if b.negative {
+		sign = packedNegative
+	}
+
+	// Digits and sign fill whole bytes, so an even number of digits
+	// gets a leading zero nibble
+	pad := 1 - n%2
+	total := pad + n + 1
+	nibble := func(k int) byte {
+		switch {
+		case k < pad:
+			return 0
+		case k == total-1:
+			return sign
+		default:
+			return digitAt(b, n-1-(k-pad))
+		}
+	}
+	for k := 0; k < total; k += 2 {
+		dst = append(dst, nibble(k)<<4|nibble(k+1))
+	}
+	return dst, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see AppendBinary.
+func (b *BCD) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the format
+// of AppendBinary.
+func (b *BCD) UnmarshalBinary(data []byte) error {
+	parsed, err := decodeBinary(data)
+	if err != nil {
+		return err
+	}
+	*b = *parsed
+	return nil
+}
+
+// decodeBinary decodes the format of AppendBinary.
+func decodeBinary(data []byte) (*BCD, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty binary decimal", ErrInvalidFormat)
+	}
+	f := form(data[0] &^ binaryNegative)
+	negative := data[0]&binaryNegative != 0
+	switch {
+	case f > signalingNaN:
+		return nil, fmt.Errorf("%w: invalid binary header 0x%02x", ErrInvalidFormat, data[0])
+	case f != finite:
+		if len(data) != 1 {
+			return nil, fmt.Errorf("%w: data after special value", ErrInvalidFormat)
+		}
+		return &BCD{digits: []uint8{0}, form: f, negative: negative}, nil
+	case negative:
+		return nil, fmt.Errorf("%w: sign bit of finite value", ErrInvalidFormat)
+	}
+
+	// Like decimal exponents the scale is limited, so a few bytes
+	// cannot request huge numbers of digits
+	scale, n := binary.Uvarint(data[1:])
+	if n <= 0 || scale > maxDecimalExponent {
+		return nil, fmt.Errorf("%w: invalid binary scale", ErrInvalidFormat)
+	}
+	packed := data[1+n:]
+	if len(packed) == 0 {
+		return nil, fmt.Errorf("%w: missing packed digits", ErrInvalidFormat)
+	}
+
+	// Unpack the nibbles, the last one is the sign
+	digits := make([]uint8, 0, 2*len(packed)-1)
+	for i := len(packed) - 1; i >= 0; i-- {
+		hi, lo := packed[i]>>4, packed[i]&0x0F
+		if i < len(packed)-1 {
+			digits = append(digits, lo)
+		}
+		digits = append(digits, hi)
+	}
+	sign := packed[len(packed)-1] & 0x0F
+	if sign != packedPositive && sign != packedNegative {
+		return nil, fmt.Errorf("%w: invalid sign nibble 0x%x", ErrInvalidFormat, sign)
+	}
+	for _, d := range digits {
+		if d > 9 {
+			return nil, fmt.Errorf("%w: invalid digit nibble 0x%x", ErrInvalidFormat, d)
+		}
+	}
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	return &BCD{
+		digits:   digits,
+		scale:    int(scale),
+		negative: sign == packedNegative,
+	}, nil
+}
+
+// AppendBinary implements encoding.BinaryAppender. The amount is written
+// as the three letters of its currency code followed by the binary form
+// of its value.
+func (c *Amount) AppendBinary(dst []byte) ([]byte, error) {
+	if c.amount == nil {
+		return nil, ErrInvalidAmount
+	}
+	dst = append(dst, c.info.Code...)
+	return c.amount.AppendBinary(dst)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see AppendBinary.
+func (c *Amount) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the format
+// of AppendBinary.
+func (c *Amount) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("%w: binary amount too short", ErrInvalidAmount)
+	}
+	value, err := decodeBinary(data[3:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	a, err := NewAmount(value, string(data[:3]))
+	if err != nil {
+		return err
+	}
+	*c = *a
+	return nil
+}
+
+// magnitudeBytes returns the magnitude of the integer digits as unsigned
+// big-endian binary number without leading zero bytes. Zero returns an
+// empty slice.
+func magnitudeBytes(digits []uint8) []byte {
+	n := significantLength(digits)
+	work := make([]uint8, n)
+	copy(work, digits[:n])
+
+	var out []byte
+	for n > 0 {
+		// Divide the remaining digits by 256, the remainder is the next byte
+		rem := 0
+		for i := n - 1; i >= 0; i-- {
+			v := rem*10 + int(work[i])
+			work[i] = uint8(v / 256)
+			rem = v % 256
+		}
+		out = append(out, byte(rem))
+		for n > 0 && work[n-1] == 0 {
+			n--
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// digitsFromBytes returns the little-endian digits of an unsigned
+// big-endian binary number.
+func digitsFromBytes(data []byte) []uint8 {
+	var digits []uint8
+	for _, b := range data {
+		carry := int(b)
+		for i := range digits {
+			v := int(digits[i])*256 + carry
+			digits[i] = uint8(v % 10)
+			carry = v / 10
+		}
+		for carry > 0 {
+			digits = append(digits, uint8(carry%10))
+			carry /= 10
+		}
+	}
+	if len(digits) == 0 {
+		return []uint8{0}
+	}
+	return digits
+}
@@ -0,0 +1,102 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding"
+	"encoding/hex"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// Both types are usable by binary marshaler consumers.
+var (
+	_ encoding.BinaryMarshaler   = (*BCD)(nil)
+	_ encoding.BinaryUnmarshaler = (*BCD)(nil)
+	_ encoding.BinaryAppender    = (*BCD)(nil)
+	_ encoding.BinaryMarshaler   = (*Amount)(nil)
+	_ encoding.BinaryUnmarshaler = (*Amount)(nil)
+)
+
+func TestBinary(t *testing.T) {
+	tests := []struct {
+		value  *BCD
+		binary string
+	}{
+		{Must("123"), "0000123c"},
+		{Must("-123"), "0000123d"},
+		{Must("12.34"), "000201234c"},
+		{Must("0"), "00000c"},
+		{Must("-0"), "00000d"},
+		{Must("0.001"), "00031c"},
+		{&BCD{digits: []uint8{0, 5, 1}, scale: 2}, "0002150c"},
+		{Must("1234567890123456789012345"), "00001234567890123456789012345c"},
+		{Inf(1), "01"},
+		{Inf(-1), "81"},
+		{NaN(), "02"},
+		{SignalingNaN(), "03"},
+	}
+	for _, test := range tests {
+		data, err := test.value.MarshalBinary()
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.binary, test.value.String())
+
+		var back BCD
+		verify.NoError(t, back.UnmarshalBinary(data))
+		verify.True(t, back.Identical(test.value), test.value.String())
+		verify.True(t, len(back.digits) > 0, test.value.String())
+	}
+
+	// The scale is limited like decimal exponents
+	var b BCD
+	verify.NoError(t, b.UnmarshalBinary(fromHex(t, "0080301c")))
+	verify.Equal(t, b.scale, maxDecimalExponent)
+	verify.True(t, b.Add(Must("1")).GreaterThan(Must("1")))
+
+	for _, s := range []string{"", "04", "0100", "80", "00", "0000", "00001a", "0000ac", "00ffffffff0f1c", "0081301c"} {
+		var b BCD
+		verify.IsError(t, b.UnmarshalBinary(fromHex(t, s)), ErrInvalidFormat)
+	}
+}
+
+func TestBinaryAmount(t *testing.T) {
+	a := MustNewAmount("19.99", "USD")
+	data, err := a.MarshalBinary()
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "555344000201999c")
+
+	var back Amount
+	verify.NoError(t, back.UnmarshalBinary(data))
+	verify.True(t, back.Equal(a))
+
+	verify.IsError(t, back.UnmarshalBinary([]byte("EUR")), ErrInvalidAmount)
+	verify.IsError(t, back.UnmarshalBinary(append([]byte("XXX"), data[3:]...)), ErrUnknownCurrency)
+	verify.IsError(t, back.UnmarshalBinary([]byte("EUR\x01")), ErrInvalidAmount)
+	verify.IsError(t, back.UnmarshalBinary([]byte("EUR\x04")), ErrInvalidFormat)
+}
+
+func TestMagnitudeBytes(t *testing.T) {
+	tests := []struct {
+		value string
+		bytes string
+	}{
+		{"0", ""},
+		{"255", "ff"},
+		{"256", "0100"},
+		{"18446744073709551616", "010000000000000000"},
+		{"340282366920938463463374607431768211455", "ffffffffffffffffffffffffffffffff"},
+	}
+	for _, test := range tests {
+		b := Must(test.value)
+		data := magnitudeBytes(b.digits)
+		verify.Equal(t, hex.EncodeToString(data), test.bytes)
+		back := &BCD{digits: digitsFromBytes(data)}
+		verify.Equal(t, back.String(), test.value)
+	}
+}
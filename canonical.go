@@ -0,0 +1,137 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"cmp"
+)
+
+// FNV-1a parameters for Hash.
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// Canonical returns the canonical string of the value: no trailing zeros
+// after the decimal point and zero without sign. Values which are Equal
+// have the same canonical string, so it can be used as map key or as
+// input for signatures. Infinities are "Inf" and "-Inf", NaNs are "NaN"
+// or "sNaN" without sign.
+func (b *BCD) Canonical() string {
+	switch {
+	case b.IsNaN():
+		if b.form == signalingNaN {
+			return "sNaN"
+		}
+		return "NaN"
+	case b.form == infinite:
+		return b.specialString()
+	case b.IsZero():
+		return "0"
+	}
+	n := b.Normalize()
+	n.digits = n.digits[:significantLength(n.digits)]
+	return n.String()
+}
+
+// Hash returns a hash of the value consistent with Equal, so values
+// like 1.0 and 1.00 or 0 and -0 have the same hash. It does not
+// allocate memory.
+func (b *BCD) Hash() uint64 {
+	h := uint64(fnvOffset)
+	write := func(c byte) {
+		h ^= uint64(c)
+		h *= fnvPrime
+	}
+
+	switch {
+	case b.IsNaN():
+		write('N')
+		return h
+	case b.form == infinite:
+		write('I')
+		if b.negative {
+			write('-')
+		}
+		return h
+	case b.IsZero():
+		write('0')
+		return h
+	}
+
+	// Hash the significant digits and the exponent of the normalized value
+	hi := significantLength(b.digits)
+	lo := 0
+	for lo < hi && b.digits[lo] == 0 {
+		lo++
+	}
+	if b.negative {
+		write('-')
+	}
+	for i := hi - 1; i >= lo; i-- {
+		write(b.digits[i])
+	}
+	exponent := uint64(lo - b.scale)
+	for range 8 {
+		write(byte(exponent))
+		exponent >>= 8
+	}
+	return h
+}
+
+// CmpTotal compares two values by the totalOrder predicate of IEEE 754.
+// Different to Cmp it distinguishes the representation: -NaN < -Inf <
+// negative numbers < -0 < 0 < positive numbers < Inf < NaN, and for
+// equal values the one with more decimal places is closer to zero, e.g.
+// 1.00 < 1.0 < 1 and -1 < -1.0 < -1.00. A signaling NaN is closer to
+// zero than a quiet one. CmpTotal can be used with slices.SortFunc.
+func (b *BCD) CmpTotal(other *BCD) int {
+	// Different signs including zeros and NaNs
+	if b.negative != other.negative {
+		if b.negative {
+			return -1
+		}
+		return 1
+	}
+
+	// Same signs, compare the positive order and flip it for negatives
+	c := totalMagnitudeOrder(b, other)
+	if b.negative {
+		return -c
+	}
+	return c
+}
+
+// totalMagnitudeOrder compares the absolute values in total order.
+func totalMagnitudeOrder(a, b *BCD) int {
+	rank := func(x *BCD) int {
+		switch x.form {
+		case infinite:
+			return 1
+		case signalingNaN:
+			return 2
+		case quietNaN:
+			return 3
+		default:
+			return 0
+		}
+	}
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 || a.form != finite {
+		return c
+	}
+	if c := compareMagnitudes(a, b); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.scale, a.scale)
+}
+
+// Identical returns true if both values have the same representation,
+// including sign and scale. So 1.0 and 1.00 are Equal but not Identical.
+func (b *BCD) Identical(other *BCD) bool {
+	return b.CmpTotal(other) == 0
+}
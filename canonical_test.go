@@ -0,0 +1,111 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// withScale returns the value of s padded with zeros to the scale.
+func withScale(s string, scale int) *BCD {
+	b := Must(s)
+	b.digits = append(make([]uint8, scale-b.scale), b.digits...)
+	b.scale = scale
+	return b
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		value *BCD
+		want  string
+	}{
+		{Must("1"), "1"},
+		{withScale("1", 3), "1"},
+		{withScale("-12.5", 4), "-12.5"},
+		{Must("100"), "100"},
+		{Must("0.001"), "0.001"},
+		{withScale("0", 2), "0"},
+		{NegZero(), "0"},
+		{Inf(-1), "-Inf"},
+		{NaN().Neg(), "NaN"},
+		{SignalingNaN(), "sNaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			verify.Equal(t, tt.value.Canonical(), tt.want)
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	equal := [][]*BCD{
+		{Must("1"), withScale("1", 1), withScale("1", 5)},
+		{Zero(), NegZero(), withScale("0", 3)},
+		{Must("-0.5"), withScale("-0.5", 2)},
+		{Must("100"), Must("100.0")},
+	}
+	seen := map[uint64]bool{}
+	for _, group := range equal {
+		h := group[0].Hash()
+		for _, v := range group[1:] {
+			verify.True(t, v.Equal(group[0]))
+			verify.Equal(t, v.Hash(), h)
+		}
+		verify.False(t, seen[h])
+		seen[h] = true
+	}
+
+	// Different values are very unlikely to collide
+	for _, s := range []string{"10", "0.1", "-1", "1.01", "Inf", "-Inf", "NaN"} {
+		h := Must(s).Hash()
+		verify.False(t, seen[h], s)
+		seen[h] = true
+	}
+}
+
+func TestCmpTotal(t *testing.T) {
+	// Ascending in total order
+	ordered := []*BCD{
+		NaN().Neg(),
+		Inf(-1),
+		Must("-2"),
+		Must("-1"),
+		withScale("-1", 1),
+		withScale("-1", 2),
+		Must("-0.5"),
+		NegZero(),
+		withScale("0", 2),
+		Zero(),
+		withScale("0.5", 3),
+		Must("0.5"),
+		withScale("1", 2),
+		withScale("1", 1),
+		Must("1"),
+		Inf(1),
+		SignalingNaN(),
+		NaN(),
+	}
+	for i := range ordered {
+		for j := range ordered {
+			verify.Equal(t, ordered[i].CmpTotal(ordered[j]), cmp.Compare(i, j), ordered[i].String()+" <> "+ordered[j].String())
+			verify.Equal(t, ordered[i].Identical(ordered[j]), i == j)
+		}
+	}
+
+	shuffled := slices.Clone(ordered)
+	slices.Reverse(shuffled)
+	slices.SortFunc(shuffled, (*BCD).CmpTotal)
+	for i := range ordered {
+		verify.True(t, shuffled[i].Identical(ordered[i]))
+	}
+}
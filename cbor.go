@@ -0,0 +1,402 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// CBOR major types and tags of RFC 8949.
+const (
+	cborUnsigned    = 0
+	cborNegative    = 1
+	cborBytes       = 2
+	cborText        = 3
+	cborArray       = 4
+	cborTag         = 6
+	cborSimple      = 7
+	cborTagPositive = 2
+	cborTagNegative = 3
+	cborTagDecimal  = 4
+)
+
+// Half precision floats for the special values.
+const (
+	cborHalfInf      = 0x7c00
+	cborHalfQuietNaN = 0x7e00
+	cborHalfSignNaN  = 0x7c01
+	cborHalfSign     = 0x8000
+)
+
+// MarshalCBOR returns the value as CBOR decimal fraction, tag 4 with the
+// array of exponent and mantissa. Mantissas beyond 64 bits are bignums
+// with tag 2 or 3. CBOR cannot express infinities, NaNs and negative
+// zero as decimal fractions, so they are half precision floats.
+func (b *BCD) MarshalCBOR() ([]byte, error) {
+	return b.appendCBOR(nil), nil
+}
+
+// UnmarshalCBOR reads a CBOR decimal fraction. Integers, bignums and
+// floats are accepted too, so values written by other encoders can be
+// read.
+func (b *BCD) UnmarshalCBOR(data []byte) error {
+	d := &cborDecoder{data: data}
+	parsed, err := d.decimal()
+	if err == nil {
+		err = d.end()
+	}
+	if err != nil {
+		return err
+	}
+	*b = *parsed
+	return nil
+}
+
+// MarshalCBOR returns the amount as CBOR array of the currency code as
+// text and the value as decimal fraction, e.g. ["EUR", 4([-2, 12345])].
+func (c *Amount) MarshalCBOR() ([]byte, error) {
+	if c.amount == nil {
+		return nil, ErrInvalidAmount
+	}
+	dst := appendCBORHead(nil, cborArray, 2)
+	dst = appendCBORHead(dst, cborText, uint64(len(c.info.Code)))
+	dst = append(dst, c.info.Code...)
+	return c.amount.appendCBOR(dst), nil
+}
+
+// UnmarshalCBOR reads the array of currency code and value of
+// MarshalCBOR.
+func (c *Amount) UnmarshalCBOR(data []byte) error {
+	d := &cborDecoder{data: data}
+	code, value, err := d.amount()
+	if err == nil {
+		err = d.end()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	a, err := NewAmount(value, code)
+	if err != nil {
+		return err
+	}
+	*c = *a
+	return nil
+}
+
+// appendCBOR appends the value as decimal fraction or special float.
+func (b *BCD) appendCBOR(dst []byte) []byte {
+	var half uint16
+	switch {
+	case b.form == infinite:
+		half = cborHalfInf
+	case b.form == quietNaN:
+		half = cborHalfQuietNaN
+	case b.form == signalingNaN:
+		half = cborHalfSignNaN
+	case b.isNegZero():
+		half = 0
+	default:
+		dst = appendCBORHead(dst, cborTag, cborTagDecimal)
+		dst = appendCBORHead(dst, cborArray, 2)
+		if b.scale > 0 {
+			dst = appendCBORHead(dst, cborNegative, uint64(b.scale-1))
+		} else {
+			dst = appendCBORHead(dst, cborUnsigned, 0)
+		}
+		return appendCBORInteger(dst, magnitudeBytes(b.digits), b.IsNegative())
+	}
+	if b.negative {
+		half |= cborHalfSign
+	}
+	return binary.BigEndian.AppendUint16(append(dst, cborSimple<<5|25), half)
+}
+
+// appendCBORInteger appends the big-endian magnitude as integer, or as
+// bignum if it does not fit into 64 bits.
+func appendCBORInteger(dst []byte, magnitude []byte, negative bool) []byte {
+	major, tag := byte(cborUnsigned), uint64(cborTagPositive)
+	if negative {
+		// Negative integers are encoded as -1 - n
+		major, tag = cborNegative, cborTagNegative
+		magnitude = decrementBytes(magnitude)
+	}
+	if len(magnitude) <= 8 {
+		var n uint64
+		for _, b := range magnitude {
+			n = n<<8 | uint64(b)
+		}
+		return appendCBORHead(dst, major, n)
+	}
+	dst = appendCBORHead(dst, cborTag, tag)
+	dst = appendCBORHead(dst, cborBytes, uint64(len(magnitude)))
+	return append(dst, magnitude...)
+}
+
+// appendCBORHead appends the head of a data item in its shortest form.
+func appendCBORHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(dst, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, major|27), n)
+	}
+}
+
+// cborDecoder reads data items from CBOR data.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// end checks that all data has been read.
+func (d *cborDecoder) end() error {
+	if d.pos != len(d.data) {
+		return fmt.Errorf("%w: %d bytes after CBOR data item", ErrInvalidFormat, len(d.data)-d.pos)
+	}
+	return nil
+}
+
+// head reads the head of the next data item. Indefinite lengths are not
+// supported.
+func (d *cborDecoder) head() (major, info byte, n uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of CBOR data", ErrInvalidFormat)
+	}
+	major, info = d.data[d.pos]>>5, d.data[d.pos]&0x1f
+	d.pos++
+	if info < 24 {
+		return major, info, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, 0, fmt.Errorf("%w: unsupported CBOR additional information %d", ErrInvalidFormat, info)
+	}
+	size := 1 << (info - 24)
+	if d.pos+size > len(d.data) {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of CBOR data", ErrInvalidFormat)
+	}
+	for _, b := range d.data[d.pos : d.pos+size] {
+		n = n<<8 | uint64(b)
+	}
+	d.pos += size
+	return major, info, n, nil
+}
+
+// bytes reads n bytes of a byte or text string.
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("%w: unexpected end of CBOR data", ErrInvalidFormat)
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// decimal reads a decimal fraction, an integer, a bignum, or a float.
+func (d *cborDecoder) decimal() (*BCD, error) {
+	start := d.pos
+	major, info, n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case major == cborTag && n == cborTagDecimal:
+		return d.fraction()
+	case major == cborSimple:
+		return d.float(info, n)
+	}
+	d.pos = start
+	return d.integer()
+}
+
+// fraction reads the array of exponent and mantissa after tag 4.
+func (d *cborDecoder) fraction() (*BCD, error) {
+	major, _, n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	if major != cborArray || n != 2 {
+		return nil, fmt.Errorf("%w: CBOR decimal fraction needs an array of two items", ErrInvalidFormat)
+	}
+	major, _, n, err = d.head()
+	if err != nil {
+		return nil, err
+	}
+	var exp int
+	switch {
+	case major == cborUnsigned && n <= maxDecimalExponent:
+		exp = int(n)
+	case major == cborNegative && n < maxDecimalExponent:
+		exp = -1 - int(n)
+	case major == cborUnsigned, major == cborNegative:
+		return nil, fmt.Errorf("%w: CBOR decimal exponent out of range", ErrOverflow)
+	default:
+		return nil, fmt.Errorf("%w: CBOR decimal exponent is no integer", ErrInvalidFormat)
+	}
+	mantissa, err := d.integer()
+	if err != nil {
+		return nil, err
+	}
+	return applyExponent(mantissa, exp), nil
+}
+
+// integer reads an integer or a bignum.
+func (d *cborDecoder) integer() (*BCD, error) {
+	major, _, n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	var magnitude []byte
+	negative := major == cborNegative
+	switch {
+	case major == cborUnsigned, major == cborNegative:
+		magnitude = binary.BigEndian.AppendUint64(nil, n)
+	case major == cborTag && (n == cborTagPositive || n == cborTagNegative):
+		negative = n == cborTagNegative
+		major, _, n, err = d.head()
+		if err != nil {
+			return nil, err
+		}
+		if major != cborBytes {
+			return nil, fmt.Errorf("%w: CBOR bignum needs a byte string", ErrInvalidFormat)
+		}
+		if magnitude, err = d.bytes(n); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: no CBOR decimal or integer", ErrInvalidFormat)
+	}
+	if negative {
+		// Negative integers are encoded as -1 - n
+		magnitude = incrementBytes(magnitude)
+	}
+	return &BCD{digits: digitsFromBytes(magnitude), negative: negative}, nil
+}
+
+// float reads a half, single, or double precision float.
+func (d *cborDecoder) float(info byte, bits uint64) (*BCD, error) {
+	var f float64
+	var quiet bool
+	var bitSize int
+	switch info {
+	case 25:
+		f, quiet, bitSize = halfToFloat64(uint16(bits)), bits&0x0200 != 0, 32
+	case 26:
+		f, quiet, bitSize = float64(math.Float32frombits(uint32(bits))), bits&0x00400000 != 0, 32
+	case 27:
+		f, quiet, bitSize = math.Float64frombits(bits), bits&(1<<51) != 0, 64
+	default:
+		return nil, fmt.Errorf("%w: no CBOR decimal or float", ErrInvalidFormat)
+	}
+	switch {
+	case math.IsNaN(f):
+		b := NaN()
+		if !quiet {
+			b = SignalingNaN()
+		}
+		b.negative = math.Signbit(f)
+		return b, nil
+	case math.IsInf(f, 0):
+		return Inf(int(math.Copysign(1, f))), nil
+	case f == 0:
+		if math.Signbit(f) {
+			return NegZero(), nil
+		}
+		return Zero(), nil
+	}
+	return FromGoogleDecimal(strconv.FormatFloat(f, 'e', -1, bitSize))
+}
+
+// amount reads the array of currency code and value.
+func (d *cborDecoder) amount() (string, *BCD, error) {
+	major, _, n, err := d.head()
+	if err != nil {
+		return "", nil, err
+	}
+	if major != cborArray || n != 2 {
+		return "", nil, fmt.Errorf("%w: CBOR amount needs an array of two items", ErrInvalidFormat)
+	}
+	major, _, n, err = d.head()
+	if err != nil {
+		return "", nil, err
+	}
+	if major != cborText {
+		return "", nil, fmt.Errorf("%w: CBOR amount needs a currency code", ErrInvalidFormat)
+	}
+	code, err := d.bytes(n)
+	if err != nil {
+		return "", nil, err
+	}
+	value, err := d.decimal()
+	if err != nil {
+		return "", nil, err
+	}
+	return string(code), value, nil
+}
+
+// halfToFloat64 converts a half precision float.
+func halfToFloat64(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&cborHalfSign != 0 {
+		f = -f
+	}
+	return f
+}
+
+// incrementBytes returns the big-endian number plus one.
+func incrementBytes(data []byte) []byte {
+	out := append([]byte{0}, data...)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i]++
+		if out[i] != 0 {
+			break
+		}
+	}
+	for len(out) > 0 && out[0] == 0 {
+		out = out[1:]
+	}
+	return out
+}
+
+// decrementBytes returns the positive big-endian number minus one
+// without leading zero bytes.
+func decrementBytes(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i]--
+		if out[i] != 0xff {
+			break
+		}
+	}
+	for len(out) > 0 && out[0] == 0 {
+		out = out[1:]
+	}
+	return out
+}
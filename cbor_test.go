@@ -0,0 +1,141 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// fromHex decodes a hex string of a test vector.
+func fromHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	verify.NoError(t, err)
+	return data
+}
+
+func TestCBOREncoding(t *testing.T) {
+	tests := []struct {
+		value string
+		cbor  string
+	}{
+		// Example of RFC 8949 section 3.4.4
+		{"273.15", "c48221196ab3"},
+		{"0", "c4820000"},
+		{"1", "c4820001"},
+		{"-1", "c4820020"},
+		{"1.5", "c482200f"},
+		{"-1.5", "c482202e"},
+		{"1000", "c482001903e8"},
+		{"-0.001", "c4822220"},
+		{"18446744073709551615", "c482001bffffffffffffffff"},
+		{"-18446744073709551616", "c482003bffffffffffffffff"},
+		// Bignums of RFC 8949 appendix A
+		{"18446744073709551616", "c48200c249010000000000000000"},
+		{"-18446744073709551617", "c48200c349010000000000000000"},
+		{"1844674407370955161.6", "c48220c249010000000000000000"},
+		{"-0", "f98000"},
+		{"Inf", "f97c00"},
+		{"-Inf", "f9fc00"},
+		{"NaN", "f97e00"},
+		{"sNaN", "f97c01"},
+	}
+	for _, test := range tests {
+		b := Must(test.value)
+		data, err := b.MarshalCBOR()
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.cbor, test.value)
+
+		var back BCD
+		verify.NoError(t, back.UnmarshalCBOR(data))
+		verify.True(t, back.Identical(b), test.value)
+	}
+}
+
+func TestCBORDecoding(t *testing.T) {
+	tests := []struct {
+		cbor     string
+		expected string
+	}{
+		// Integers, bignums and floats of RFC 8949 appendix A
+		{"00", "0"},
+		{"17", "23"},
+		{"1818", "24"},
+		{"1903e8", "1000"},
+		{"1a000f4240", "1000000"},
+		{"1b000000e8d4a51000", "1000000000000"},
+		{"1bffffffffffffffff", "18446744073709551615"},
+		{"c249010000000000000000", "18446744073709551616"},
+		{"3bffffffffffffffff", "-18446744073709551616"},
+		{"c349010000000000000000", "-18446744073709551617"},
+		{"20", "-1"},
+		{"3903e7", "-1000"},
+		{"f90000", "0"},
+		{"f98000", "-0"},
+		{"f93c00", "1"},
+		{"fb3ff199999999999a", "1.1"},
+		{"f93e00", "1.5"},
+		{"f97bff", "65504"},
+		{"fa47c35000", "100000"},
+		{"f90001", "0.000000059604645"},
+		{"fbc010666666666666", "-4.1"},
+		{"f97c00", "Inf"},
+		{"f9fc00", "-Inf"},
+		{"fa7f800000", "Inf"},
+		{"fb7ff8000000000000", "NaN"},
+		// Decimal fractions with positive exponent and bignum exponents
+		{"c4820205", "500"},
+		{"c482381b01", "0.0000000000000000000000000001"},
+	}
+	for _, test := range tests {
+		var b BCD
+		verify.NoError(t, b.UnmarshalCBOR(fromHex(t, test.cbor)))
+		verify.Equal(t, b.String(), test.expected, test.cbor)
+	}
+
+	invalid := []struct {
+		cbor string
+		err  error
+	}{
+		{"", ErrInvalidFormat},
+		{"c482", ErrInvalidFormat},
+		{"c48100", ErrInvalidFormat},
+		{"c4826161", ErrInvalidFormat},
+		{"c48200", ErrInvalidFormat},
+		{"c4821a0001000001", ErrOverflow},
+		{"c249", ErrInvalidFormat},
+		{"c26101", ErrInvalidFormat},
+		{"6131", ErrInvalidFormat},
+		{"f5", ErrInvalidFormat},
+		{"9f00ff", ErrInvalidFormat},
+		{"0000", ErrInvalidFormat},
+	}
+	for _, test := range invalid {
+		var b BCD
+		verify.IsError(t, b.UnmarshalCBOR(fromHex(t, test.cbor)), test.err)
+	}
+}
+
+func TestCBORAmount(t *testing.T) {
+	a := MustNewAmount("-123.45", "EUR")
+	data, err := a.MarshalCBOR()
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "8263455552c48221393038")
+
+	var back Amount
+	verify.NoError(t, back.UnmarshalCBOR(data))
+	verify.True(t, back.Equal(a))
+
+	verify.IsError(t, back.UnmarshalCBOR(fromHex(t, "82635858581864")), ErrUnknownCurrency)
+	verify.IsError(t, back.UnmarshalCBOR(fromHex(t, "826345555218")), ErrInvalidAmount)
+	verify.IsError(t, back.UnmarshalCBOR(fromHex(t, "8263455552f97c00")), ErrInvalidAmount)
+	verify.IsError(t, back.UnmarshalCBOR(fromHex(t, "c4820000")), ErrInvalidAmount)
+	verify.IsError(t, back.UnmarshalCBOR(fromHex(t, "c4820000")), ErrInvalidFormat)
+}
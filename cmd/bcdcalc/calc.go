@@ -0,0 +1,356 @@
+// Tideland Go BCD - Calculator
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tideland.dev/go/bcd"
+	"tideland.dev/go/bcd/expr"
+)
+
+// Calculator errors.
+var (
+	errUsage = errors.New("usage")
+	errQuit  = errors.New("quit")
+)
+
+// assignment matches "name = expression" but not comparisons.
+var assignment = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)\s*=([^=].*)$`)
+
+// calculator evaluates lines of input and keeps the variables and
+// the history of results.
+type calculator struct {
+	scale   int
+	mode    bcd.RoundingMode
+	vars    expr.Vars
+	results int
+}
+
+// newCalculator creates a calculator with the default scale and rounding
+// mode for divisions.
+func newCalculator(scale int, mode bcd.RoundingMode) *calculator {
+	return &calculator{
+		scale: scale,
+		mode:  mode,
+		vars:  expr.Vars{},
+	}
+}
+
+// execute executes one line and returns the output lines. Results are
+// stored as history variables _ and _1, _2, and so on. With labeled
+// output every result is prefixed with its history variable.
+func (c *calculator) execute(line string, labeled bool) ([]string, error) {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "" || strings.HasPrefix(line, "#"):
+		return nil, nil
+	case strings.HasPrefix(line, ":"):
+		return c.command(line[1:])
+	}
+
+	if m := assignment.FindStringSubmatch(line); m != nil {
+		if strings.HasPrefix(m[1], "_") {
+			return nil, fmt.Errorf("history variable %s cannot be assigned", m[1])
+		}
+		v, err := c.eval(m[2])
+		if err != nil {
+			return nil, err
+		}
+		c.vars[m[1]] = v
+		return []string{m[1] + " = " + v.String()}, nil
+	}
+
+	v, err := c.eval(line)
+	if err != nil {
+		return nil, err
+	}
+	name := c.remember(v)
+	if labeled {
+		return []string{name + " = " + v.String()}, nil
+	}
+	return []string{v.String()}, nil
+}
+
+// eval evaluates an expression with the calculator functions.
+func (c *calculator) eval(source string) (expr.Value, error) {
+	return expr.Eval(source, c.vars,
+		expr.WithScale(c.scale),
+		expr.WithRoundingMode(c.mode),
+		expr.WithFunc("parse", parseFunc),
+		expr.WithFunc("minor", minorFunc),
+		expr.WithFunc("fromminor", fromMinorFunc),
+	)
+}
+
+// remember stores the value in the history and returns its name.
+func (c *calculator) remember(v expr.Value) string {
+	c.results++
+	name := "_" + strconv.Itoa(c.results)
+	c.vars["_"] = v
+	c.vars[name] = v
+	return name
+}
+
+// command executes a calculator command.
+func (c *calculator) command(line string) ([]string, error) {
+	name, args, _ := strings.Cut(line, " ")
+	switch name {
+	case "quit", "q":
+		return nil, errQuit
+	case "help", "h":
+		return strings.Split(help, "\n"), nil
+	case "vars", "v":
+		return c.listVars(), nil
+	case "scale":
+		return c.setScale(args)
+	case "mode":
+		return c.setMode(args)
+	case "round", "r":
+		return c.roundAll(args)
+	case "split", "s":
+		return c.split(args)
+	case "allocate", "a":
+		return c.allocate(args)
+	case "format", "f":
+		return c.format(args)
+	default:
+		return nil, fmt.Errorf("unknown command :%s, see :help", name)
+	}
+}
+
+// listVars returns all variables sorted by name.
+func (c *calculator) listVars() []string {
+	var names []string
+	for name := range c.vars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s = %v", name, c.vars[name])
+	}
+	return lines
+}
+
+// setScale sets or shows the default division scale.
+func (c *calculator) setScale(args string) ([]string, error) {
+	if args = strings.TrimSpace(args); args != "" {
+		scale, err := strconv.Atoi(args)
+		if err != nil || scale < 0 {
+			return nil, fmt.Errorf("%w: :scale <non-negative integer>", errUsage)
+		}
+		c.scale = scale
+	}
+	return []string{"scale = " + strconv.Itoa(c.scale)}, nil
+}
+
+// setMode sets or shows the default rounding mode.
+func (c *calculator) setMode(args string) ([]string, error) {
+	if args = strings.TrimSpace(args); args != "" {
+		mode, err := bcd.ParseRoundingMode(args)
+		if err != nil {
+			return nil, err
+		}
+		c.mode = mode
+	}
+	return []string{"mode = " + c.mode.String()}, nil
+}
+
+// roundAll shows the value rounded with every rounding mode.
+func (c *calculator) roundAll(args string) ([]string, error) {
+	values, err := c.arguments(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2 || values[0].Kind() != expr.KindNumber {
+		return nil, fmt.Errorf("%w: :round <number>, <places>", errUsage)
+	}
+	places, ok := integerArg(values[1])
+	if !ok || places < 0 {
+		return nil, fmt.Errorf("%w: :round <number>, <places>", errUsage)
+	}
+	var lines []string
+	for mode := bcd.RoundDown; mode <= bcd.RoundFloor; mode++ {
+		lines = append(lines, fmt.Sprintf("%-10s %s", mode, values[0].Number().Round(int(places), mode)))
+	}
+	return lines, nil
+}
+
+// split shows the amount split into n parts.
+func (c *calculator) split(args string) ([]string, error) {
+	values, err := c.arguments(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2 || values[0].Kind() != expr.KindAmount {
+		return nil, fmt.Errorf("%w: :split <amount>, <parts>", errUsage)
+	}
+	n, ok := integerArg(values[1])
+	if !ok {
+		return nil, fmt.Errorf("%w: :split <amount>, <parts>", errUsage)
+	}
+	shares, err := values[0].Amount().Split(int(n))
+	if err != nil {
+		return nil, err
+	}
+	return c.shares(shares), nil
+}
+
+// allocate shows the amount allocated by the ratios.
+func (c *calculator) allocate(args string) ([]string, error) {
+	values, err := c.arguments(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 2 || values[0].Kind() != expr.KindAmount {
+		return nil, fmt.Errorf("%w: :allocate <amount>, <ratio>, ...", errUsage)
+	}
+	ratios := make([]int, len(values)-1)
+	for i, v := range values[1:] {
+		r, ok := integerArg(v)
+		if !ok {
+			return nil, fmt.Errorf("%w: :allocate <amount>, <ratio>, ...", errUsage)
+		}
+		ratios[i] = int(r)
+	}
+	shares, err := values[0].Amount().Allocate(ratios)
+	if err != nil {
+		return nil, err
+	}
+	return c.shares(shares), nil
+}
+
+// integerArg returns the value as integer. Values with a fraction, of
+// another kind, or beyond int64 return false instead of being truncated.
+func integerArg(v expr.Value) (int64, bool) {
+	if v.Kind() != expr.KindNumber || v.Number().Normalize().Scale() != 0 {
+		return 0, false
+	}
+	i, err := v.Number().ToInt64()
+	return i, err == nil
+}
+
+// shares stores the shares in the history and returns the output lines.
+func (c *calculator) shares(shares []*bcd.Amount) []string {
+	lines := make([]string, len(shares))
+	for i, share := range shares {
+		v := expr.AmountValue(share)
+		lines[i] = c.remember(v) + " = " + v.String()
+	}
+	return lines
+}
+
+// format shows the amount in the different formats.
+func (c *calculator) format(args string) ([]string, error) {
+	values, err := c.arguments(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 || values[0].Kind() != expr.KindAmount {
+		return nil, fmt.Errorf("%w: :format <amount>", errUsage)
+	}
+	a := values[0].Amount()
+	minor, err := a.ToMinorUnits()
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		"symbol     " + a.Format(true, false),
+		"code       " + a.Format(false, true),
+		"grouped    " + a.FormatWithSeparators(",", true, false),
+		"minor      " + strconv.FormatInt(minor, 10),
+		"currency   " + a.Name(),
+	}, nil
+}
+
+// arguments evaluates the comma separated command arguments.
+func (c *calculator) arguments(args string) ([]expr.Value, error) {
+	var values []expr.Value
+	collect := func(args []expr.Value) (expr.Value, error) {
+		values = args
+		return expr.BoolValue(true), nil
+	}
+	_, err := expr.Eval("args("+args+")", c.vars,
+		expr.WithScale(c.scale),
+		expr.WithRoundingMode(c.mode),
+		expr.WithFunc("args", collect),
+		expr.WithFunc("parse", parseFunc),
+		expr.WithFunc("minor", minorFunc),
+		expr.WithFunc("fromminor", fromMinorFunc),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// parseFunc implements parse("€1.234,56").
+func parseFunc(args []expr.Value) (expr.Value, error) {
+	if len(args) != 1 || args[0].Kind() != expr.KindString {
+		return expr.Value{}, fmt.Errorf("%w: parse(\"<formatted amount>\")", expr.ErrArgument)
+	}
+	a, err := bcd.ParseAmount(args[0].String())
+	if err != nil {
+		return expr.Value{}, err
+	}
+	return expr.AmountValue(a), nil
+}
+
+// minorFunc implements minor(amount).
+func minorFunc(args []expr.Value) (expr.Value, error) {
+	if len(args) != 1 || args[0].Kind() != expr.KindAmount {
+		return expr.Value{}, fmt.Errorf("%w: minor(<amount>)", expr.ErrArgument)
+	}
+	units, err := args[0].Amount().ToMinorUnits()
+	if err != nil {
+		return expr.Value{}, err
+	}
+	return expr.NumberValue(bcd.Must(units)), nil
+}
+
+// fromMinorFunc implements fromminor(units, "EUR").
+func fromMinorFunc(args []expr.Value) (expr.Value, error) {
+	if len(args) != 2 || args[0].Kind() != expr.KindNumber || args[1].Kind() != expr.KindString {
+		return expr.Value{}, fmt.Errorf("%w: fromminor(<units>, \"<code>\")", expr.ErrArgument)
+	}
+	units, ok := integerArg(args[0])
+	if !ok {
+		return expr.Value{}, fmt.Errorf("%w: fromminor(<units>, \"<code>\")", expr.ErrArgument)
+	}
+	a, err := bcd.NewAmountMinor(units, args[1].String())
+	if err != nil {
+		return expr.Value{}, err
+	}
+	return expr.AmountValue(a), nil
+}
+
+// help describes expressions and commands.
+const help = `Expressions
+  1 + 2 * 3, (a - b) / 4, 7 % 3       arithmetic, / uses the default scale
+  == != < <= > >= && || !             comparisons and logic
+  round(x, 2, "half-up")              modes: down, up, half-up, half-down,
+                                      half-even, ceiling, floor
+  floor ceil trunc div min max abs sqrt cmp if
+  amount(12.5, "EUR")                 amount of a currency
+  parse("€1.234,56")                  parse a formatted amount
+  minor(x), fromminor(1234, "EUR")    convert from and to minor units
+  net = 100 * 1.19                    assign a variable
+  _, _1, _2, ...                      history of results
+Commands
+  :round x, places                    round with every mode
+  :split amount, parts                split evenly
+  :allocate amount, ratio, ...        allocate by ratios
+  :format amount                      show formats and minor units
+  :scale [n], :mode [name]            show or set the defaults
+  :vars, :help, :quit`
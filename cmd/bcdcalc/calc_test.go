@@ -0,0 +1,86 @@
+// Tideland Go BCD - Calculator - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+)
+
+func TestCalculator(t *testing.T) {
+	calc := newCalculator(4, bcd.RoundHalfEven)
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"1 / 3", "_1 = 0.3333"},
+		{"_ * 3", "_2 = 0.9999"},
+		{"round(2.5, 0)", "_3 = 2"},
+		{"round(2.5, 0, \"half-up\")", "_4 = 3"},
+		{"bill = amount(100, \"EUR\")", "bill = 100.00 EUR"},
+		{":split bill, 3", "_5 = 33.34 EUR|_6 = 33.33 EUR|_7 = 33.33 EUR"},
+		{":allocate bill, 1, 3", "_8 = 25.00 EUR|_9 = 75.00 EUR"},
+		{"_6 + _7", "_10 = 66.66 EUR"},
+		{"parse(\"€1.234,56\")", "_11 = 1234.56 EUR"},
+		{"minor(_)", "_12 = 123456"},
+		{"fromminor(1999, \"USD\")", "_13 = 19.99 USD"},
+		{":round -2.345, 2", "down       -2.34|up         -2.35|half-up    -2.35|half-down  -2.34|half-even  -2.34|ceiling    -2.34|floor      -2.35"},
+		{":format amount(1234.5, \"USD\")", "symbol     $1234.50|code       1234.50 USD|grouped    $1,234.50|minor      123450|currency   US Dollar"},
+		{":scale 2", "scale = 2"},
+		{":mode up", "mode = up"},
+		{"1 / 3", "_14 = 0.34"},
+		{"# comment", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			lines, err := calc.execute(tt.line, true)
+			verify.NoError(t, err)
+			verify.Equal(t, strings.Join(lines, "|"), tt.want)
+		})
+	}
+}
+
+func TestCalculatorErrors(t *testing.T) {
+	calc := newCalculator(10, bcd.RoundHalfEven)
+	for _, line := range []string{
+		"1 +",
+		"_1 = 2",
+		":split 100, 3",
+		":round 1.5",
+		":allocate amount(100, \"EUR\"), 1, 0.5",
+		":split amount(100, \"EUR\"), \"two\"",
+		"fromminor(19.99, \"USD\")",
+		":mode sideways",
+		":unknown",
+		"parse(\"nonsense\")",
+	} {
+		_, err := calc.execute(line, true)
+		verify.Error(t, err)
+	}
+
+	// Fractional counts are rejected instead of truncated
+	_, err := calc.execute(":split amount(100, \"EUR\"), 2.7", true)
+	verify.IsError(t, err, errUsage)
+	_, err = calc.execute(":round 1.25, 1.9", true)
+	verify.IsError(t, err, errUsage)
+}
+
+func TestRunLines(t *testing.T) {
+	in := strings.NewReader("1 + 1\n\n2 *\n_ * 10\n:quit\n3\n")
+	var out, errOut bytes.Buffer
+	code := runLines(newCalculator(10, bcd.RoundHalfEven), in, &out, &errOut)
+	verify.Equal(t, code, 1)
+	verify.Equal(t, out.String(), "2\n20\n")
+	verify.True(t, strings.HasPrefix(errOut.String(), "line 3:"))
+}
@@ -0,0 +1,127 @@
+// Tideland Go BCD - Calculator
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Command bcdcalc is an exact decimal calculator for checking rounding,
+// allocation, and currency results without writing Go code.
+//
+// Usage:
+//
+//	bcdcalc [--scale n] [--mode name] [expression ...]
+//
+// With expressions as arguments each one is evaluated and printed. Without
+// arguments bcdcalc starts an interactive session if stdin is a terminal,
+// otherwise it reads one expression per line from stdin and prints the
+// results, e.g. for scripting:
+//
+//	echo 'round(10 / 3, 2, "up")' | bcdcalc
+//
+// Enter :help in the interactive session for the supported expressions
+// and commands.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"tideland.dev/go/bcd"
+	"tideland.dev/go/bcd/expr"
+)
+
+func main() {
+	mode := bcd.RoundHalfEven
+	scale := flag.Int("scale", 10, "default scale of divisions")
+	flag.Var(&mode, "mode", "default rounding mode")
+	batch := flag.Bool("batch", false, "read expressions from stdin even if it is a terminal")
+	flag.Parse()
+
+	if *scale < 0 {
+		fmt.Fprintln(os.Stderr, "bcdcalc: invalid --scale")
+		os.Exit(2)
+	}
+	calc := newCalculator(*scale, mode)
+
+	switch {
+	case flag.NArg() > 0:
+		os.Exit(runLines(calc, strings.NewReader(strings.Join(flag.Args(), "\n")), os.Stdout, os.Stderr))
+	case *batch || !isTerminal(os.Stdin):
+		os.Exit(runLines(calc, os.Stdin, os.Stdout, os.Stderr))
+	default:
+		runInteractive(calc, os.Stdin, os.Stdout)
+	}
+}
+
+// runLines executes all lines without prompt and returns the exit code,
+// 1 if any line failed.
+func runLines(calc *calculator, in io.Reader, out, errOut io.Writer) int {
+	code := 0
+	scanner := bufio.NewScanner(in)
+	for n := 1; scanner.Scan(); n++ {
+		lines, err := calc.execute(scanner.Text(), false)
+		if errors.Is(err, errQuit) {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(errOut, "line %d: %v\n", n, err)
+			code = 1
+			continue
+		}
+		for _, line := range lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+	return code
+}
+
+// runInteractive runs the read-eval-print loop until :quit or EOF.
+func runInteractive(calc *calculator, in io.Reader, out io.Writer) {
+	fmt.Fprintln(out, "bcdcalc - exact decimal calculator, :help for help")
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return
+		}
+		input := scanner.Text()
+		lines, err := calc.execute(input, true)
+		if errors.Is(err, errQuit) {
+			return
+		}
+		if err != nil {
+			printError(out, input, err)
+			continue
+		}
+		for _, line := range lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+// printError prints the error, marking the position of expression errors.
+func printError(out io.Writer, input string, err error) {
+	var e *expr.Error
+	if errors.As(err, &e) && !strings.HasPrefix(strings.TrimSpace(input), ":") {
+		// Positions refer to the expression after an assignment
+		offset := len(input) - len(strings.TrimLeft(input, " \t"))
+		if m := assignment.FindStringSubmatchIndex(strings.TrimSpace(input)); m != nil {
+			offset += m[4]
+		}
+		fmt.Fprintf(out, "  %s^\n", strings.Repeat(" ", offset+e.Pos-1))
+	}
+	fmt.Fprintln(out, "error:", err)
+}
+
+// isTerminal returns true if the file is a character device.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,172 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// ErrNoValues is returned by collection helpers needing at least one value.
+var ErrNoValues = fmt.Errorf("no values")
+
+// Decimal is the type constraint of the generic comparison and collection
+// helpers, which work on BCD values as well as on amounts. Amounts are
+// checked for a single currency up front, so a mixture returns one
+// ErrCurrencyMismatch instead of failing per element.
+type Decimal interface {
+	*BCD | *Amount
+}
+
+// Compare compares two values for use with slices.SortFunc and similar
+// functions. BCD values are compared like Cmp. Amounts with different
+// currencies are ordered by their currency code first, so the comparison
+// never fails.
+func Compare[T Decimal](a, b T) int {
+	switch a := any(a).(type) {
+	case *Amount:
+		b := any(b).(*Amount)
+		return cmp.Or(cmp.Compare(a.info.Code, b.info.Code), a.amount.Cmp(b.amount))
+	default:
+		return a.(*BCD).Cmp(any(b).(*BCD))
+	}
+}
+
+// CompareDesc compares two values in descending order, see Compare.
+func CompareDesc[T Decimal](a, b T) int {
+	return Compare(b, a)
+}
+
+// Min returns the smallest of the values.
+func Min[T Decimal](values ...T) (T, error) {
+	return extremum(values, -1)
+}
+
+// Max returns the largest of the values.
+func Max[T Decimal](values ...T) (T, error) {
+	return extremum(values, 1)
+}
+
+// extremum returns the smallest value for sign -1, the largest for sign 1.
+func extremum[T Decimal](values []T, sign int) (T, error) {
+	var zero T
+	if len(values) == 0 {
+		return zero, ErrNoValues
+	}
+	if _, err := commonCurrency(values...); err != nil {
+		return zero, err
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if Compare(v, m) == sign {
+			m = v
+		}
+	}
+	return m, nil
+}
+
+// Clamp returns x limited to the range from lo to hi.
+func Clamp[T Decimal](x, lo, hi T) (T, error) {
+	var zero T
+	if _, err := commonCurrency(x, lo, hi); err != nil {
+		return zero, err
+	}
+	if Compare(lo, hi) > 0 {
+		return zero, fmt.Errorf("%w: lower bound %v is greater than upper bound %v", ErrInvalidOperation, lo, hi)
+	}
+	switch {
+	case Compare(x, lo) < 0:
+		return lo, nil
+	case Compare(x, hi) > 0:
+		return hi, nil
+	default:
+		return x, nil
+	}
+}
+
+// SumOf returns the exact sum of the values. The sum of no BCD values
+// is zero, the sum of no amounts returns ErrNoValues as the currency
+// is unknown.
+func SumOf[T Decimal](values ...T) (T, error) {
+	var zero T
+	info, err := commonCurrency(values...)
+	if err != nil {
+		return zero, err
+	}
+
+	s := NewSum()
+	for _, v := range values {
+		s.Add(decimalOf(v))
+	}
+
+	if _, ok := any(zero).(*Amount); ok {
+		if len(values) == 0 {
+			return zero, ErrNoValues
+		}
+		return any(&Amount{amount: s.Result(), info: info}).(T), nil
+	}
+	return any(s.Result()).(T), nil
+}
+
+// SortAsc sorts the values in ascending order. Equal values keep their
+// order.
+func SortAsc[T Decimal](values []T) error {
+	if _, err := commonCurrency(values...); err != nil {
+		return err
+	}
+	slices.SortStableFunc(values, Compare[T])
+	return nil
+}
+
+// SortDesc sorts the values in descending order. Equal values keep their
+// order.
+func SortDesc[T Decimal](values []T) error {
+	if _, err := commonCurrency(values...); err != nil {
+		return err
+	}
+	slices.SortStableFunc(values, CompareDesc[T])
+	return nil
+}
+
+// ApproxEqual returns true if a and b differ by no more than the
+// tolerance. NaNs are never approximately equal.
+func ApproxEqual[T Decimal](a, b T, tolerance *BCD) (bool, error) {
+	if _, err := commonCurrency(a, b); err != nil {
+		return false, err
+	}
+	diff := decimalOf(a).Sub(decimalOf(b)).Abs()
+	return diff.LessOrEqual(tolerance.Abs()), nil
+}
+
+// decimalOf returns the BCD of a value.
+func decimalOf[T Decimal](v T) *BCD {
+	if a, ok := any(v).(*Amount); ok {
+		return a.amount
+	}
+	return any(v).(*BCD)
+}
+
+// commonCurrency returns the currency of amounts and checks that all
+// amounts share it. For BCD values it returns empty information.
+func commonCurrency[T Decimal](values ...T) (CurrencyInfo, error) {
+	var info CurrencyInfo
+	for _, v := range values {
+		a, ok := any(v).(*Amount)
+		if !ok {
+			return info, nil
+		}
+		switch {
+		case info.Code == "":
+			info = a.info
+		case info.Code != a.info.Code:
+			return info, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, info.Code, a.info.Code)
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,117 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// joined returns the values as comma separated string.
+func joined[T Decimal](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = decimalOf(v).String()
+	}
+	return strings.Join(s, ",")
+}
+
+func TestCollectionBCD(t *testing.T) {
+	values := []*BCD{Must("3.5"), Must("-1"), Must("10"), Must("0.25")}
+
+	m, err := Min(values...)
+	verify.NoError(t, err)
+	verify.Equal(t, m.String(), "-1")
+	m, err = Max(values...)
+	verify.NoError(t, err)
+	verify.Equal(t, m.String(), "10")
+	_, err = Min[*BCD]()
+	verify.IsError(t, err, ErrNoValues)
+
+	c, err := Clamp(Must("12"), Must(0), Must(10))
+	verify.NoError(t, err)
+	verify.Equal(t, c.String(), "10")
+	c, err = Clamp(Must("-0.5"), Must(0), Must(10))
+	verify.NoError(t, err)
+	verify.Equal(t, c.String(), "0")
+	_, err = Clamp(Must(1), Must(10), Must(0))
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	s, err := SumOf(values...)
+	verify.NoError(t, err)
+	verify.Equal(t, s.String(), "12.75")
+	s, err = SumOf[*BCD]()
+	verify.NoError(t, err)
+	verify.Equal(t, s.String(), "0")
+
+	sorted := slices.Clone(values)
+	verify.NoError(t, SortAsc(sorted))
+	verify.Equal(t, joined(sorted), "-1,0.25,3.5,10")
+	verify.NoError(t, SortDesc(sorted))
+	verify.Equal(t, joined(sorted), "10,3.5,0.25,-1")
+	slices.SortFunc(sorted, Compare)
+	verify.Equal(t, joined(sorted), "-1,0.25,3.5,10")
+
+	ok, err := ApproxEqual(Must("1.004"), Must("1"), Must("0.005"))
+	verify.NoError(t, err)
+	verify.True(t, ok)
+	ok, _ = ApproxEqual(Must("1.006"), Must("1"), Must("0.005"))
+	verify.False(t, ok)
+	ok, _ = ApproxEqual(NaN(), NaN(), Must("1"))
+	verify.False(t, ok)
+}
+
+func TestCollectionAmount(t *testing.T) {
+	amounts := []*Amount{
+		MustNewAmount("20.00", "EUR"),
+		MustNewAmount("5.50", "EUR"),
+		MustNewAmount("12.25", "EUR"),
+	}
+
+	m, err := Min(amounts...)
+	verify.NoError(t, err)
+	verify.Equal(t, m.Format(false, true), "5.50 EUR")
+	m, err = Max(amounts...)
+	verify.NoError(t, err)
+	verify.Equal(t, m.Format(false, true), "20.00 EUR")
+
+	s, err := SumOf(amounts...)
+	verify.NoError(t, err)
+	verify.Equal(t, s.Format(false, true), "37.75 EUR")
+	_, err = SumOf[*Amount]()
+	verify.IsError(t, err, ErrNoValues)
+
+	c, err := Clamp(amounts[0], amounts[1], amounts[2])
+	verify.NoError(t, err)
+	verify.Equal(t, c.Format(false, true), "12.25 EUR")
+
+	verify.NoError(t, SortAsc(amounts))
+	verify.Equal(t, joined(amounts), "5.5,12.25,20")
+
+	ok, err := ApproxEqual(amounts[0], MustNewAmount("5.49", "EUR"), Must("0.01"))
+	verify.NoError(t, err)
+	verify.True(t, ok)
+
+	// Mixed currencies fail once up front
+	mixed := append(slices.Clone(amounts), MustNewAmount("1", "USD"), MustNewAmount("2", "GBP"))
+	_, err = Min(mixed...)
+	verify.IsError(t, err, ErrCurrencyMismatch)
+	_, err = SumOf(mixed...)
+	verify.IsError(t, err, ErrCurrencyMismatch)
+	verify.IsError(t, SortDesc(mixed), ErrCurrencyMismatch)
+	verify.Equal(t, joined(mixed), "5.5,12.25,20,1,2")
+	_, err = ApproxEqual(amounts[0], mixed[3], Must(1))
+	verify.IsError(t, err, ErrCurrencyMismatch)
+
+	// The comparator orders by currency first and never fails
+	slices.SortFunc(mixed, CompareDesc)
+	verify.Equal(t, joined(mixed), "1,2,20,12.25,5.5")
+}
@@ -53,6 +53,15 @@
 //	// Zero value
 //	n9 := bcd.Zero()  // 0
 //
+// For large text exports ParseBytes parses directly from byte slices, and
+// BCD and Amount implement fmt.Scanner for fmt.Fscan. AppendText and
+// AppendFormat append to a buffer like the strconv.Append functions:
+//
+//	var price bcd.BCD
+//	var fee bcd.Amount
+//	fmt.Fscan(r, &price, &fee)            // "19.99 2.50 EUR"
+//	buf = price.AppendFormat(buf, 2, bcd.RoundHalfEven)
+//
 // # Arithmetic Operations
 //
 // The BCD type supports all basic arithmetic operations:
@@ -66,6 +75,22 @@
 //	quot, _ := a.Div(b, 4, bcd.RoundHalfUp) // 3.2308
 //	rem, _ := a.Mod(b)                      // 0.75
 //
+// # Mutable Arithmetic
+//
+// All BCD operations return new values. For hot loops the Var type
+// offers a math/big-style API setting the receiver to the result while
+// reusing its digit buffers, and Sum adds up many values without
+// allocations once the buffers are large enough:
+//
+//	z := bcd.NewVar(bcd.Zero())
+//	z.Mul(price, quantity).Round(&z.BCD, 2, bcd.RoundHalfEven)
+//
+//	sum := bcd.NewSum()
+//	for _, row := range rows {
+//		sum.Add(row)
+//	}
+//	total := sum.Result()
+//
 // # Rounding
 //
 // The package provides seven rounding modes for fine control over decimal
@@ -83,6 +108,72 @@
 // Banker's rounding (RoundHalfEven) is particularly useful for financial
 // applications as it minimizes cumulative rounding bias.
 //
+// Rounding modes have names like "half-even", see String and
+// ParseRoundingMode.
+//
+// # Configuration
+//
+// BCD, Amount and RoundingMode implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler, so they can be read from JSON, YAML, TOML or
+// environment variables by the libraries using these interfaces. Amounts
+// are written as "1234.50 EUR". All three also implement flag.Value:
+//
+//	limit := bcd.Flag("limit", bcd.Must(1000), "maximum transfer")
+//	fee := bcd.AmountFlag("fee", bcd.MustNewAmount("0.30", "EUR"), "fee per transfer")
+//	mode := bcd.RoundHalfEven
+//	flag.Var(&mode, "mode", "rounding mode")
+//
+// In XML a BCD is written as character data, an Amount additionally gets
+// its currency as attribute like in <Amt Ccy="EUR">123.45</Amt>. The name
+// of the attribute is XMLCurrencyAttr, and amounts with more decimal
+// places than their currency are rejected. For other schemas the fields
+// use XMLAmount with its own attribute name and scale setting.
+//
+// For gRPC APIs MoneyParts has the layout of google.type.Money with
+// units and nanos of the same sign. ToGoogleDecimal and FromGoogleDecimal
+// convert from and to the strict string form of google.type.Decimal:
+//
+//	parts, err := amount.ToMoneyParts()  // {EUR -1 -750000000}
+//	amount, err = bcd.FromMoneyParts(parts)
+//	d, err := bcd.FromGoogleDecimal("1.25E+3")
+//
+// Binary encodings are dependency-free too. MarshalBinary writes packed
+// decimal digits with a sign nibble, MarshalCBOR the CBOR decimal fraction
+// of tag 4 with bignum mantissas of tags 2 and 3, and MarshalMsgpack a
+// MessagePack extension of type MsgpackExtBCD or MsgpackExtAmount. The
+// method names match the interfaces of the common CBOR and MessagePack
+// packages. A MsgpackCodec uses other extension types.
+//
+// For the decimal logical type of Avro and Parquet ToUnscaledBytes returns
+// the value rounded to a scale as big-endian two's complement integer,
+// optionally with a fixed length. ToUnscaledInt32 and ToUnscaledInt64
+// cover the int32 and int64 physical types. Values beyond the declared
+// precision return ErrOverflow:
+//
+//	data, err := price.ToUnscaledBytes(38, 4, 16, bcd.RoundHalfEven)
+//	price, err = bcd.FromUnscaledBytes(data, 4)
+//
+// Whole columns convert to the buffers of the Arrow decimal128 and
+// decimal256 types with NewArrowDecimal128 and NewArrowDecimal256, nil
+// values become nulls of the validity bitmap. The ArrowDecimal can be
+// handed to any Arrow implementation without importing it here:
+//
+//	col, err := bcd.NewArrowDecimal128(prices, 38, 4, bcd.RoundHalfEven)
+//	prices, err = col.Values()
+//
+// EncodePGNumeric and DecodePGNumeric handle the binary format of the
+// PostgreSQL NUMERIC type for COPY BINARY, keeping the scale of a value
+// as display scale as well as NaN and the infinities. The native formats
+// of MySQL DECIMAL in rows and binlogs and of Oracle NUMBER are covered
+// by EncodeMySQLDecimal and EncodeOracleNumber with their decoders.
+//
+// Fixed-width record fields with an implied decimal point are written by
+// FormatFixedWidth and read by ParseFixedWidth, with leading, trailing, or
+// overpunched signs. ControlTotal and HashTotal verify batch trailers:
+//
+//	field, err := amount.FormatFixedWidth(10, 2, bcd.SignOverpunch)
+//	value, err := bcd.ParseFixedWidth("000001234N", 2, bcd.SignOverpunch)
+//
 // # Amount Type
 //
 // The Amount type combines BCD arithmetic with currency-specific features:
@@ -150,6 +241,121 @@
 // Each currency has the correct number of decimal places according to ISO 4217
 // standards (e.g., 2 for USD, 0 for JPY, 8 for BTC).
 //
+// GetCurrencyInfoByNumericCode finds a currency by its numeric code as
+// used by card messages. NewAmountFromISO8583 creates an Amount from an
+// ISO 8583 amount of twelve digits in minor units, ToISO8583 returns it,
+// and ParseISO8583AdditionalAmounts reads the amounts of field 54:
+//
+//	amount, err := bcd.NewAmountFromISO8583("000000012345", "978")
+//
+// Words spells out an amount for cheques and contracts in English,
+// German, French, or Spanish with the names of the currency units, up to
+// WordsMaxDigits integer digits. WithChequeFraction writes the minor
+// units as fraction:
+//
+//	words, err := amount.Words("en", bcd.WithChequeFraction())
+//	// "One thousand two hundred thirty-four dollars and 56/100"
+//
+// # Special Values
+//
+// Following IEEE 754-2008 a BCD can also be a quiet or signaling NaN,
+// positive or negative infinity, or a negative zero. They propagate
+// through all operations, e.g. Inf - Inf is NaN, and parse and format
+// as "NaN", "sNaN", "Inf", "-Inf", and "-0":
+//
+//	x := bcd.Must("1").Quo(bcd.Zero(), 2, bcd.RoundHalfEven)  // Inf
+//	x.IsInf(1)                                              // true
+//	bcd.NaN().Add(x).IsNaN()                                // true
+//	bcd.Must("-0").Signbit()                                // true
+//
+// For finite values the error-returning API keeps working as before, so
+// Div still returns ErrDivisionByZero for a zero divisor. A signaling
+// NaN makes those operations fail with ErrInvalidOperation. Amounts are
+// always finite, NewAmount, MulChecked, and Div return ErrInvalidAmount
+// for special values.
+//
+// # IEEE 754 Interchange Formats
+//
+// BCD values convert to and from the 64 and 128 bit decimal interchange
+// formats in both the binary integer (BID) and the densely packed decimal
+// (DPD) encoding. Coefficients exceeding the format precision are rounded
+// with the given mode, exponents out of range return ErrOverflow:
+//
+//	data, err := bcd.Must("123.45").ToDecimal128BID(bcd.RoundHalfEven)
+//	x := bcd.FromDecimal128BID(data)  // 123.45
+//
+// The byte arrays are big-endian. Systems like MongoDB store decimal128
+// little-endian and need the bytes reversed.
+//
+// # Rational Numbers
+//
+// Div always needs a finite scale, so 1/3 can never be represented
+// exactly by a BCD. The Rat type keeps numerator and denominator as
+// BCD integers instead and converts back only when needed:
+//
+//	third, _ := bcd.NewRat(bcd.Must(1), bcd.Must(3))
+//	third.ToBCD(4, bcd.RoundHalfEven)  // 0.3333
+//	third.RepeatingString(20)          // 0.(3)
+//	r, _ := bcd.ParseRat("1 1/2")      // 3/2
+//
+// # Interval Arithmetic
+//
+// An Interval encloses a value between a lower and an upper bound.
+// Rounding operations round the lower bound with RoundFloor and the
+// upper bound with RoundCeiling, so the true result stays enclosed:
+//
+//	third, _ := bcd.PointInterval(bcd.Must(1)).Div(bcd.PointInterval(bcd.Must(3)), 4)
+//	third.String()  // [0.3333, 0.3334]
+//	third.Width()   // 0.0001
+//
+// AmountInterval does the same for monetary amounts at the decimal places
+// of their currency, e.g. for worst-case exposure reports.
+//
+// # Statistics
+//
+// The subpackage stats provides exact aggregates like sum, mean, median,
+// percentiles, variance and standard deviation over slices of BCD values.
+// Intermediate results are exact, only the final result is rounded:
+//
+//	mean, _ := stats.Mean(values, 2, bcd.RoundHalfEven)
+//	stddev, _ := stats.SampleStdDev(values, 4, bcd.RoundHalfEven)
+//
+// For streaming data a stats.Accumulator collects values incrementally.
+//
+// # Expressions
+//
+// The subpackage expr parses and evaluates formulas with variables bound
+// to BCD or Amount values, e.g. fee and tax rules kept in configuration:
+//
+//	v, err := expr.Eval("round(net * 0.19, 2) + max(fee, 1.50)", expr.Vars{
+//		"net": net,
+//		"fee": fee,
+//	})
+//
+// # Payment Messages
+//
+// The subpackage iso20022 validates amounts against the rules of the
+// ISO 20022 ActiveCurrencyAndAmount type and marshals them as elements
+// with currency attribute, e.g. <InstdAmt Ccy="EUR">12.50</InstdAmt>:
+//
+//	text, err := iso20022.Format(amount)
+//	amount, err = iso20022.Parse("12.50", "EUR")
+//
+// The subpackage swift handles the amounts of SWIFT MT messages with
+// decimal comma and at most 15 characters, as well as the composites of
+// the fields 32A, 33B, and the balances like 60F:
+//
+//	text, err := swift.FormatAmount(amount)
+//	balance, err := swift.ParseBalance("C250915EUR1234,56")
+//
+// Bank statements in the formats camt.053 and MT940 are imported by the
+// subpackage statement. Verify checks exactly that the opening balance
+// plus credits minus debits equals the closing balance and reports the
+// line where a statement does not reconcile:
+//
+//	statements, err := statement.ParseMT940(file)
+//	err = statement.Verify(statements)
+//
 // # Comparison Operations
 //
 // Both BCD and Amount types support comparison operations:
@@ -163,6 +369,23 @@
 //	c.GreaterThan(a)    // true
 //	a.Cmp(c)            // -1 (a < c)
 //
+// Equal values may differ in their scale, like 1.0 and 1.00. Canonical
+// returns the same string for them and Hash the same hash, so they can be
+// used as map keys. CmpTotal orders by the IEEE 754 totalOrder including
+// sign and scale, Identical compares the exact representation:
+//
+//	set[x.Canonical()] = x
+//	slices.SortFunc(values, (*bcd.BCD).CmpTotal)
+//
+// The generic helpers Min, Max, Clamp, SumOf, SortAsc, SortDesc and
+// ApproxEqual work on slices of BCD values as well as of amounts. For
+// amounts the currency is checked once up front. Compare and CompareDesc
+// are comparators for the slices package:
+//
+//	largest, err := bcd.Max(invoices...)
+//	total, err := bcd.SumOf(invoices...)
+//	slices.SortFunc(prices, bcd.Compare)
+//
 // # Error Handling
 //
 // The package defines several error types for common issues:
@@ -171,9 +394,11 @@
 //   - ErrDivisionByZero: Attempted division by zero
 //   - ErrOverflow: Arithmetic overflow
 //   - ErrPrecisionLoss: Loss of precision in conversion
+//   - ErrInvalidOperation: Operation not defined for the operands, e.g. square root of a negative number
 //   - ErrUnknownCurrency: Unknown currency code
 //   - ErrCurrencyMismatch: Operation on different currencies
 //   - ErrInvalidAmount: Invalid amount for currency operation
+//   - ErrNoValues: Collection helper called without values
 //
 // # Performance Considerations
 //
@@ -9,6 +9,7 @@ package bcd_test
 
 import (
 	"fmt"
+	"slices"
 
 	"tideland.dev/go/bcd"
 )
@@ -375,6 +376,33 @@ func ExampleAmount_invoice() {
 	// Total:                               $521.08
 }
 
+// ExampleBCD_Canonical demonstrates sets of decimals keyed by their
+// canonical form and sorted by total order.
+func ExampleBCD_Canonical() {
+	values := []*bcd.BCD{
+		bcd.Must(1),
+		bcd.Must("1.499").Add(bcd.Must("0.001")), // 1.500
+		bcd.Must("1.5"),
+		bcd.Must("-0"),
+		bcd.Must("0"),
+	}
+
+	// Values which are equal share the canonical key
+	set := map[string]*bcd.BCD{}
+	for _, v := range values {
+		set[v.Canonical()] = v
+	}
+	fmt.Println(len(set))
+
+	// Total order distinguishes sign and scale
+	slices.SortFunc(values, (*bcd.BCD).CmpTotal)
+	fmt.Println(values)
+
+	// Output:
+	// 3
+	// [-0 0 1 1.500 1.5]
+}
+
 func repeatString(s string, n int) string {
 	result := ""
 	for range n {
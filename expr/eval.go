@@ -0,0 +1,244 @@
+// Tideland Go BCD - Expressions
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package expr
+
+import (
+	"fmt"
+
+	"tideland.dev/go/bcd"
+)
+
+// evaluator evaluates the nodes of an expression.
+type evaluator struct {
+	vars Vars
+	cfg  config
+}
+
+// eval evaluates a node.
+func (ev *evaluator) eval(n node) (Value, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return NumberValue(n.value), nil
+	case *stringNode:
+		return StringValue(n.value), nil
+	case *varNode:
+		return ev.variable(n)
+	case *unaryNode:
+		return ev.unary(n)
+	case *binaryNode:
+		return ev.binary(n)
+	case *callNode:
+		return ev.call(n)
+	default:
+		return Value{}, newError(n.pos(), fmt.Errorf("%w: unknown node %T", ErrSyntax, n))
+	}
+}
+
+// variable returns the value bound to the variable.
+func (ev *evaluator) variable(n *varNode) (Value, error) {
+	raw, ok := ev.vars[n.name]
+	if !ok {
+		return Value{}, newError(n.p, fmt.Errorf("%w: %s", ErrUnknownVariable, n.name))
+	}
+	switch v := raw.(type) {
+	case *bcd.BCD:
+		return NumberValue(v), nil
+	case *bcd.Amount:
+		return AmountValue(v), nil
+	case bool:
+		return BoolValue(v), nil
+	case string:
+		return StringValue(v), nil
+	case Value:
+		return v, nil
+	default:
+		return Value{}, newError(n.p, fmt.Errorf("%w: variable %s has unsupported type %T", ErrType, n.name, raw))
+	}
+}
+
+// unary evaluates the prefix operators.
+func (ev *evaluator) unary(n *unaryNode) (Value, error) {
+	v, err := ev.eval(n.operand)
+	if err != nil {
+		return Value{}, err
+	}
+	switch {
+	case n.op == "!" && v.kind == KindBool:
+		return BoolValue(!v.boolean), nil
+	case n.op == "+" && v.isNumeric():
+		return v, nil
+	case n.op == "-" && v.kind == KindNumber:
+		return NumberValue(v.number.Neg()), nil
+	case n.op == "-" && v.kind == KindAmount:
+		return AmountValue(v.amount.Neg()), nil
+	}
+	return Value{}, newError(n.p, fmt.Errorf("%w: %s%s", ErrType, n.op, v.kind))
+}
+
+// binary evaluates the infix operators.
+func (ev *evaluator) binary(n *binaryNode) (Value, error) {
+	left, err := ev.eval(n.left)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// Logical operators short-circuit
+	if n.op == "&&" || n.op == "||" {
+		if left.kind != KindBool {
+			return Value{}, newError(n.left.pos(), fmt.Errorf("%w: %s needs bool operands, got %s", ErrType, n.op, left.kind))
+		}
+		if (n.op == "&&") != left.boolean {
+			return left, nil
+		}
+		right, err := ev.eval(n.right)
+		if err != nil {
+			return Value{}, err
+		}
+		if right.kind != KindBool {
+			return Value{}, newError(n.right.pos(), fmt.Errorf("%w: %s needs bool operands, got %s", ErrType, n.op, right.kind))
+		}
+		return right, nil
+	}
+
+	right, err := ev.eval(n.right)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var v Value
+	switch n.op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		v, err = ev.compare(n.op, left, right)
+	default:
+		v, err = ev.arithmetic(n.op, left, right)
+	}
+	if err != nil {
+		return Value{}, newError(n.p, err)
+	}
+	return v, nil
+}
+
+// arithmetic evaluates + - * / and %. Plain numbers combined with an
+// amount take its currency, amounts can only be multiplied or divided
+// by plain numbers.
+func (ev *evaluator) arithmetic(op string, left, right Value) (Value, error) {
+	if !left.isNumeric() || !right.isNumeric() {
+		return Value{}, fmt.Errorf("%w: %s %s %s", ErrType, left.kind, op, right.kind)
+	}
+	code, err := currency(left, right)
+	if err != nil {
+		return Value{}, err
+	}
+	x, y := left.Number(), right.Number()
+
+	var result *bcd.BCD
+	switch op {
+	case "+":
+		result = x.Add(y)
+	case "-":
+		result = x.Sub(y)
+	case "*":
+		if left.kind == KindAmount && right.kind == KindAmount {
+			return Value{}, fmt.Errorf("%w: amount * amount", ErrType)
+		}
+		result = x.Mul(y)
+	case "/":
+		switch {
+		case left.kind == KindNumber && right.kind == KindAmount:
+			return Value{}, fmt.Errorf("%w: number / amount", ErrType)
+		case left.kind == KindAmount && right.kind == KindAmount:
+			// The ratio of two amounts is a plain number
+			ratio, err := x.Div(y, ev.cfg.scale, ev.cfg.mode)
+			if err != nil {
+				return Value{}, err
+			}
+			return NumberValue(ratio), nil
+		case left.kind == KindAmount:
+			// Divide directly to the decimal places of the currency
+			// to round only once
+			info, _ := bcd.GetCurrencyInfo(code)
+			result, err = x.Div(y, info.DecimalPlaces, ev.cfg.mode)
+		default:
+			result, err = x.Div(y, ev.cfg.scale, ev.cfg.mode)
+		}
+	case "%":
+		if left.kind == KindNumber && right.kind == KindAmount {
+			return Value{}, fmt.Errorf("%w: number %% amount", ErrType)
+		}
+		result, err = x.Mod(y)
+	}
+	if err != nil {
+		return Value{}, err
+	}
+	return ev.result(result, code)
+}
+
+// compare evaluates the comparison operators.
+func (ev *evaluator) compare(op string, left, right Value) (Value, error) {
+	var c int
+	switch {
+	case left.isNumeric() && right.isNumeric():
+		if _, err := currency(left, right); err != nil {
+			return Value{}, err
+		}
+		c = left.Number().Cmp(right.Number())
+	case left.kind == right.kind && (op == "==" || op == "!="):
+		if left.String() != right.String() {
+			c = 1
+		}
+	default:
+		return Value{}, fmt.Errorf("%w: %s %s %s", ErrType, left.kind, op, right.kind)
+	}
+
+	switch op {
+	case "==":
+		return BoolValue(c == 0), nil
+	case "!=":
+		return BoolValue(c != 0), nil
+	case "<":
+		return BoolValue(c < 0), nil
+	case "<=":
+		return BoolValue(c <= 0), nil
+	case ">":
+		return BoolValue(c > 0), nil
+	default:
+		return BoolValue(c >= 0), nil
+	}
+}
+
+// result returns a number value or, if code is set, an amount value
+// rounded to the decimal places of the currency.
+func (ev *evaluator) result(b *bcd.BCD, code string) (Value, error) {
+	if code == "" {
+		return NumberValue(b), nil
+	}
+	info, _ := bcd.GetCurrencyInfo(code)
+	a, err := bcd.NewAmount(b.Round(info.DecimalPlaces, ev.cfg.mode), code)
+	if err != nil {
+		return Value{}, err
+	}
+	return AmountValue(a), nil
+}
+
+// currency returns the common currency code of the values, an empty
+// string if none is an amount, or bcd.ErrCurrencyMismatch.
+func currency(values ...Value) (string, error) {
+	code := ""
+	for _, v := range values {
+		if v.kind != KindAmount {
+			continue
+		}
+		switch {
+		case code == "":
+			code = v.amount.Code()
+		case code != v.amount.Code():
+			return "", fmt.Errorf("%w: %s != %s", bcd.ErrCurrencyMismatch, code, v.amount.Code())
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,198 @@
+// Tideland Go BCD - Expressions
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Package expr parses and evaluates decimal expressions like fee and
+// tax formulas exactly with BCD values:
+//
+//	e, err := expr.Parse("round(net * 0.19, 2) + max(fee, 1.50)")
+//	v, err := e.Eval(expr.Vars{"net": net, "fee": fee})
+//
+// Expressions support the operators + - * / % with the usual precedence,
+// the comparisons == != < <= > >=, the logical operators && || !, and
+// parentheses. Variables are bound to *bcd.BCD or *bcd.Amount values.
+// A plain number combined with an amount takes the currency of the
+// amount, results of amount operations are rounded to the decimal
+// places of the currency.
+//
+// Built-in functions are
+//
+//	round(x, places[, mode])   round x, mode like "half-even"
+//	floor(x[, places])         round towards negative infinity
+//	ceil(x[, places])          round towards positive infinity
+//	trunc(x[, places])         round towards zero
+//	div(x, y[, scale[, mode]]) divide with explicit scale and mode
+//	min(x, ...), max(x, ...)   smallest or largest argument
+//	abs(x)                     absolute value
+//	sqrt(x[, scale[, mode]])   square root
+//	cmp(x, y)                  -1, 0, or 1
+//	if(cond, a, b)             a if cond is true, otherwise b
+//	amount(x, "EUR")           x as amount of the currency
+//
+// The division operator and functions without explicit scale or mode
+// use the defaults set with WithScale and WithRoundingMode. Errors are
+// of type *Error carrying the position within the source.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"tideland.dev/go/bcd"
+)
+
+// Expression errors, returned wrapped into an *Error.
+var (
+	ErrSyntax          = fmt.Errorf("syntax error")
+	ErrUnknownVariable = fmt.Errorf("unknown variable")
+	ErrUnknownFunction = fmt.Errorf("unknown function")
+	ErrArgument        = fmt.Errorf("invalid argument")
+	ErrType            = fmt.Errorf("type mismatch")
+)
+
+// Error describes a failure at a position of the expression source.
+// Pos is the 1-based byte offset. The wrapped error is one of the
+// expression errors or an error of the bcd package like
+// bcd.ErrDivisionByZero or bcd.ErrCurrencyMismatch.
+type Error struct {
+	Pos int
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("expr: position %d: %v", e.Pos, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError creates an error at the 0-based offset pos.
+func newError(pos int, err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Pos: pos + 1, Err: err}
+}
+
+// Vars binds variable names to values. Supported are *bcd.BCD,
+// *bcd.Amount, bool, string, and Value.
+type Vars map[string]any
+
+// Func is a custom function. It receives the evaluated arguments.
+type Func func(args []Value) (Value, error)
+
+// config contains the evaluation settings.
+type config struct {
+	scale int
+	mode  bcd.RoundingMode
+	funcs map[string]Func
+}
+
+// Option configures the evaluation.
+type Option func(*config)
+
+// WithScale sets the scale of divisions without explicit scale.
+// The default is 10.
+func WithScale(scale int) Option {
+	return func(c *config) {
+		c.scale = scale
+	}
+}
+
+// WithRoundingMode sets the rounding mode of divisions and roundings
+// without explicit mode. The default is bcd.RoundHalfEven.
+func WithRoundingMode(mode bcd.RoundingMode) Option {
+	return func(c *config) {
+		c.mode = mode
+	}
+}
+
+// WithFunc adds a custom function. It takes precedence over a
+// built-in function with the same name.
+func WithFunc(name string, fn Func) Option {
+	return func(c *config) {
+		c.funcs[name] = fn
+	}
+}
+
+// Expr is a parsed expression. It can be evaluated multiple times
+// with different variables and is safe for concurrent use.
+type Expr struct {
+	source string
+	root   node
+}
+
+// Parse parses the expression source.
+func Parse(source string) (*Expr, error) {
+	p, err := newParser(source)
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// MustParse parses the expression source and panics on error.
+func MustParse(source string) *Expr {
+	e, err := Parse(source)
+	if err != nil {
+		panic(fmt.Sprintf("expr.MustParse: %v", err))
+	}
+	return e
+}
+
+// Eval parses and evaluates the expression source.
+func Eval(source string, vars Vars, opts ...Option) (Value, error) {
+	e, err := Parse(source)
+	if err != nil {
+		return Value{}, err
+	}
+	return e.Eval(vars, opts...)
+}
+
+// Eval evaluates the expression with the given variables.
+func (e *Expr) Eval(vars Vars, opts ...Option) (Value, error) {
+	cfg := config{
+		scale: 10,
+		mode:  bcd.RoundHalfEven,
+		funcs: map[string]Func{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	ev := &evaluator{vars: vars, cfg: cfg}
+	return ev.eval(e.root)
+}
+
+// Source returns the original expression source.
+func (e *Expr) Source() string {
+	return e.source
+}
+
+// Vars returns the sorted names of all variables used by the expression.
+func (e *Expr) Vars() []string {
+	var names []string
+	walk(e.root, func(n node) {
+		if v, ok := n.(*varNode); ok && !slices.Contains(names, v.name) {
+			names = append(names, v.name)
+		}
+	})
+	slices.Sort(names)
+	return names
+}
+
+// String returns the expression in a normalized, fully parenthesized form.
+func (e *Expr) String() string {
+	return e.root.String()
+}
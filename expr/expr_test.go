@@ -0,0 +1,180 @@
+// Tideland Go BCD - Expressions - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package expr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+)
+
+func TestEvalNumbers(t *testing.T) {
+	vars := Vars{
+		"net": bcd.Must("100.10"),
+		"fee": bcd.Must("1.2"),
+		"vip": true,
+	}
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"1 + 2 * 3", "7"},
+		{"(1 + 2) * 3", "9"},
+		{"10 - 4 - 3", "3"},
+		{"-2 * -3", "6"},
+		{"-(1 - 1)", "0"},
+		{"7 % 3 + 0.5", "1.5"},
+		{"10.5 % 3", "1.5"},
+		{"1 / 4", "0.2500000000"},
+		{"0.1 + 0.2 == 0.3", "true"},
+		{"net * 0.19", "19.019"},
+		{"round(net * 0.19, 2) + max(fee, 1.50)", "20.52"},
+		{"round(2.5, 0)", "2"},
+		{"round(2.5, 0, \"half-up\")", "3"},
+		{"floor(-1.5)", "-2"},
+		{"ceil(1.21, 1)", "1.3"},
+		{"trunc(-1.29, 1)", "-1.2"},
+		{"div(1, 3, 4)", "0.3333"},
+		{"div(2, 3, 2, \"down\")", "0.66"},
+		{"min(3, 1, 2)", "1"},
+		{"abs(-4.5)", "4.5"},
+		{"sqrt(2, 4)", "1.4142"},
+		{"cmp(net, 50)", "1"},
+		{"if(vip, 0, fee)", "0"},
+		{"if(net > 1000 && !vip, 1, 2)", "2"},
+		{"net >= 100.1 || 1 / 0 > 0", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			v, err := Eval(tt.source, vars)
+			verify.NoError(t, err)
+			verify.Equal(t, v.String(), tt.want)
+		})
+	}
+}
+
+func TestEvalAmounts(t *testing.T) {
+	vars := Vars{
+		"net":   bcd.MustNewAmount("100.10", "EUR"),
+		"fee":   bcd.MustNewAmount("1.20", "EUR"),
+		"price": bcd.MustNewAmount("5", "USD"),
+	}
+	tests := []struct {
+		source string
+		want   string
+		kind   Kind
+	}{
+		{"net * 0.19", "19.02 EUR", KindAmount},
+		{"round(net * 0.19, 2) + max(fee, 1.50)", "20.52 EUR", KindAmount},
+		{"net / 3", "33.37 EUR", KindAmount},
+		{"net / fee", "83.4166666667", KindNumber},
+		{"net - 0.10", "100.00 EUR", KindAmount},
+		{"-fee", "-1.20 EUR", KindAmount},
+		{"abs(-fee)", "1.20 EUR", KindAmount},
+		{"fee < 1.5", "true", KindBool},
+		{"amount(12.345, \"USD\")", "12.34 USD", KindAmount},
+		{"price + amount(1, \"USD\")", "6.00 USD", KindAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			v, err := Eval(tt.source, vars)
+			verify.NoError(t, err)
+			verify.Equal(t, v.String(), tt.want)
+			verify.Equal(t, v.Kind(), tt.kind)
+		})
+	}
+}
+
+func TestEvalOptions(t *testing.T) {
+	v, err := Eval("1 / 3", nil, WithScale(2), WithRoundingMode(bcd.RoundUp))
+	verify.NoError(t, err)
+	verify.Equal(t, v.String(), "0.34")
+
+	double := func(args []Value) (Value, error) {
+		if len(args) != 1 || args[0].Kind() != KindNumber {
+			return Value{}, ErrArgument
+		}
+		return NumberValue(args[0].Number().Mul(bcd.Must(2))), nil
+	}
+	v, err = Eval("double(21)", nil, WithFunc("double", double))
+	verify.NoError(t, err)
+	verify.Equal(t, v.String(), "42")
+
+	_, err = Eval("double(1, 2)", nil, WithFunc("double", double))
+	verify.IsError(t, err, ErrArgument)
+}
+
+func TestErrors(t *testing.T) {
+	vars := Vars{
+		"eur": bcd.MustNewAmount("1", "EUR"),
+		"usd": bcd.MustNewAmount("1", "USD"),
+		"bad": 1.5,
+	}
+	tests := []struct {
+		source string
+		err    error
+		pos    int
+	}{
+		{"1 +", ErrSyntax, 4},
+		{"(1 + 2", ErrSyntax, 7},
+		{"1 $ 2", ErrSyntax, 3},
+		{"\"open", ErrSyntax, 1},
+		{"1 < 2 < 3", ErrSyntax, 7},
+		{"1 2", ErrSyntax, 3},
+		{"x + 1", ErrUnknownVariable, 1},
+		{"1 + foo(2)", ErrUnknownFunction, 5},
+		{"round(1)", ErrArgument, 1},
+		{"round(1, 2, \"sideways\")", ErrArgument, 1},
+		{"round(1, 1.5)", ErrArgument, 1},
+		{"1 + (2 > 1)", ErrType, 3},
+		{"eur * eur", ErrType, 5},
+		{"bad * 2", ErrType, 1},
+		{"if(1, 2, 3)", ErrType, 4},
+		{"2 * (1 / 0)", bcd.ErrDivisionByZero, 8},
+		{"eur + usd", bcd.ErrCurrencyMismatch, 5},
+		{"max(eur, usd)", bcd.ErrCurrencyMismatch, 1},
+		{"sqrt(-1)", bcd.ErrInvalidOperation, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			v, err := Eval(tt.source, vars)
+			verify.IsError(t, err, tt.err)
+			var e *Error
+			verify.True(t, errors.As(err, &e))
+			verify.Equal(t, e.Pos, tt.pos)
+
+			// The result of a failed evaluation is safe to use
+			verify.Equal(t, v.Kind(), KindInvalid)
+			verify.Equal(t, v.String(), "")
+			verify.True(t, v.Number() == nil)
+			verify.True(t, v.Amount() == nil)
+		})
+	}
+	verify.Equal(t, Value{}.Kind().String(), "invalid")
+}
+
+func TestExpr(t *testing.T) {
+	e := MustParse("round(net * rate, 2) - net * -1 + rate")
+	verify.Equal(t, strings.Join(e.Vars(), ","), "net,rate")
+	verify.Equal(t, e.String(), "((round((net * rate), 2) - (net * (-1))) + rate)")
+	verify.Equal(t, e.Source(), "round(net * rate, 2) - net * -1 + rate")
+
+	// Parsed expressions can be evaluated multiple times
+	for _, net := range []string{"10", "20"} {
+		v, err := e.Eval(Vars{"net": bcd.Must(net), "rate": bcd.Must("0.5")})
+		verify.NoError(t, err)
+		verify.True(t, v.Number().Equal(bcd.Must(net).Mul(bcd.Must("1.5")).Add(bcd.Must("0.5"))))
+	}
+}
@@ -0,0 +1,260 @@
+// Tideland Go BCD - Expressions
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package expr
+
+import (
+	"fmt"
+
+	"tideland.dev/go/bcd"
+)
+
+// builtin is a built-in function working on the evaluated arguments.
+type builtin struct {
+	minArgs int
+	maxArgs int // -1 for any number
+	fn      func(ev *evaluator, args []Value) (Value, error)
+}
+
+// builtins contains the built-in functions. The function if is
+// evaluated lazily by the evaluator itself.
+var builtins = map[string]builtin{
+	"round":  {2, 3, builtinRound},
+	"floor":  {1, 2, roundWith(bcd.RoundFloor)},
+	"ceil":   {1, 2, roundWith(bcd.RoundCeiling)},
+	"trunc":  {1, 2, roundWith(bcd.RoundDown)},
+	"div":    {2, 4, builtinDiv},
+	"min":    {1, -1, extremum(-1)},
+	"max":    {1, -1, extremum(1)},
+	"abs":    {1, 1, builtinAbs},
+	"sqrt":   {1, 3, builtinSqrt},
+	"cmp":    {2, 2, builtinCmp},
+	"amount": {2, 2, builtinAmount},
+}
+
+// call evaluates a function call.
+func (ev *evaluator) call(n *callNode) (Value, error) {
+	if n.name == "if" {
+		return ev.callIf(n)
+	}
+
+	args := make([]Value, len(n.args))
+	for i, arg := range n.args {
+		v, err := ev.eval(arg)
+		if err != nil {
+			return Value{}, err
+		}
+		args[i] = v
+	}
+
+	if fn, ok := ev.cfg.funcs[n.name]; ok {
+		v, err := fn(args)
+		if err != nil {
+			return Value{}, newError(n.p, err)
+		}
+		return v, nil
+	}
+
+	b, ok := builtins[n.name]
+	if !ok {
+		return Value{}, newError(n.p, fmt.Errorf("%w: %s", ErrUnknownFunction, n.name))
+	}
+	if len(args) < b.minArgs || (b.maxArgs >= 0 && len(args) > b.maxArgs) {
+		return Value{}, newError(n.p, fmt.Errorf("%w: %s called with %d arguments", ErrArgument, n.name, len(args)))
+	}
+	v, err := b.fn(ev, args)
+	if err != nil {
+		return Value{}, newError(n.p, fmt.Errorf("%s: %w", n.name, err))
+	}
+	return v, nil
+}
+
+// callIf evaluates only the branch selected by the condition.
+func (ev *evaluator) callIf(n *callNode) (Value, error) {
+	if len(n.args) != 3 {
+		return Value{}, newError(n.p, fmt.Errorf("%w: if called with %d arguments", ErrArgument, len(n.args)))
+	}
+	cond, err := ev.eval(n.args[0])
+	if err != nil {
+		return Value{}, err
+	}
+	if cond.kind != KindBool {
+		return Value{}, newError(n.args[0].pos(), fmt.Errorf("%w: if needs a bool condition, got %s", ErrType, cond.kind))
+	}
+	if cond.boolean {
+		return ev.eval(n.args[1])
+	}
+	return ev.eval(n.args[2])
+}
+
+// builtinRound implements round(x, places[, mode]).
+func builtinRound(ev *evaluator, args []Value) (Value, error) {
+	mode := ev.cfg.mode
+	if len(args) == 3 {
+		var err error
+		if mode, err = modeArg(args[2]); err != nil {
+			return Value{}, err
+		}
+	}
+	return roundWith(mode)(ev, args[:2])
+}
+
+// roundWith returns a rounding function with a fixed mode and the
+// number of places as optional second argument.
+func roundWith(mode bcd.RoundingMode) func(ev *evaluator, args []Value) (Value, error) {
+	return func(ev *evaluator, args []Value) (Value, error) {
+		x, err := numericArg(args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		places := 0
+		if len(args) > 1 {
+			if places, err = intArg(args[1]); err != nil {
+				return Value{}, err
+			}
+		}
+		code, _ := currency(x)
+		return ev.result(x.Number().Round(places, mode), code)
+	}
+}
+
+// builtinDiv implements div(x, y[, scale[, mode]]).
+func builtinDiv(ev *evaluator, args []Value) (Value, error) {
+	scale, mode := ev.cfg.scale, ev.cfg.mode
+	var err error
+	if len(args) > 2 {
+		if scale, err = intArg(args[2]); err != nil {
+			return Value{}, err
+		}
+	}
+	if len(args) > 3 {
+		if mode, err = modeArg(args[3]); err != nil {
+			return Value{}, err
+		}
+	}
+	if !args[0].isNumeric() || args[1].kind != KindNumber {
+		return Value{}, fmt.Errorf("%w: div(%s, %s)", ErrType, args[0].kind, args[1].kind)
+	}
+	q, err := args[0].Number().Div(args[1].number, scale, mode)
+	if err != nil {
+		return Value{}, err
+	}
+	code, _ := currency(args[0])
+	return ev.result(q, code)
+}
+
+// extremum returns min for sign -1 and max for sign 1.
+func extremum(sign int) func(ev *evaluator, args []Value) (Value, error) {
+	return func(ev *evaluator, args []Value) (Value, error) {
+		for _, arg := range args {
+			if _, err := numericArg(arg); err != nil {
+				return Value{}, err
+			}
+		}
+		code, err := currency(args...)
+		if err != nil {
+			return Value{}, err
+		}
+		m := args[0].Number()
+		for _, arg := range args[1:] {
+			if arg.Number().Cmp(m) == sign {
+				m = arg.Number()
+			}
+		}
+		return ev.result(m, code)
+	}
+}
+
+// builtinAbs implements abs(x).
+func builtinAbs(ev *evaluator, args []Value) (Value, error) {
+	x, err := numericArg(args[0])
+	if err != nil {
+		return Value{}, err
+	}
+	if x.kind == KindAmount {
+		return AmountValue(x.amount.Abs()), nil
+	}
+	return NumberValue(x.number.Abs()), nil
+}
+
+// builtinSqrt implements sqrt(x[, scale[, mode]]).
+func builtinSqrt(ev *evaluator, args []Value) (Value, error) {
+	if args[0].kind != KindNumber {
+		return Value{}, fmt.Errorf("%w: sqrt(%s)", ErrType, args[0].kind)
+	}
+	scale, mode := ev.cfg.scale, ev.cfg.mode
+	var err error
+	if len(args) > 1 {
+		if scale, err = intArg(args[1]); err != nil {
+			return Value{}, err
+		}
+	}
+	if len(args) > 2 {
+		if mode, err = modeArg(args[2]); err != nil {
+			return Value{}, err
+		}
+	}
+	r, err := args[0].number.Sqrt(scale, mode)
+	if err != nil {
+		return Value{}, err
+	}
+	return NumberValue(r), nil
+}
+
+// builtinCmp implements cmp(x, y).
+func builtinCmp(ev *evaluator, args []Value) (Value, error) {
+	for _, arg := range args {
+		if _, err := numericArg(arg); err != nil {
+			return Value{}, err
+		}
+	}
+	if _, err := currency(args...); err != nil {
+		return Value{}, err
+	}
+	return NumberValue(bcd.Must(args[0].Number().Cmp(args[1].Number()))), nil
+}
+
+// builtinAmount implements amount(x, code).
+func builtinAmount(ev *evaluator, args []Value) (Value, error) {
+	if args[0].kind != KindNumber || args[1].kind != KindString {
+		return Value{}, fmt.Errorf("%w: amount(%s, %s)", ErrType, args[0].kind, args[1].kind)
+	}
+	if _, ok := bcd.GetCurrencyInfo(args[1].str); !ok {
+		return Value{}, fmt.Errorf("%w: %s", bcd.ErrUnknownCurrency, args[1].str)
+	}
+	return ev.result(args[0].number, args[1].str)
+}
+
+// numericArg checks that the argument is a number or an amount.
+func numericArg(arg Value) (Value, error) {
+	if !arg.isNumeric() {
+		return Value{}, fmt.Errorf("%w: expected number or amount, got %s", ErrArgument, arg.kind)
+	}
+	return arg, nil
+}
+
+// intArg returns the argument as integer, e.g. for places and scales.
+func intArg(arg Value) (int, error) {
+	if arg.kind != KindNumber || arg.number.Normalize().Scale() != 0 {
+		return 0, fmt.Errorf("%w: expected integer, got %s", ErrArgument, arg)
+	}
+	i, err := arg.number.ToInt64()
+	if err != nil || i < 0 || i > 1000 {
+		return 0, fmt.Errorf("%w: integer %s out of range", ErrArgument, arg)
+	}
+	return int(i), nil
+}
+
+// modeArg returns the rounding mode named by the argument.
+func modeArg(arg Value) (bcd.RoundingMode, error) {
+	if arg.kind == KindString {
+		if mode, err := bcd.ParseRoundingMode(arg.str); err == nil {
+			return mode, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown rounding mode %s", ErrArgument, arg)
+}
@@ -0,0 +1,345 @@
+// Tideland Go BCD - Expressions
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tideland.dev/go/bcd"
+)
+
+// tokenKind describes the type of a token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenString
+	tokenOperator
+)
+
+// token is a lexical unit of the source with its 0-based offset.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators contains all operators, two character ones first.
+var operators = []string{
+	"<=", ">=", "==", "!=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "(", ")", ",",
+}
+
+// tokenize splits the source into tokens.
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || (c == '.' && i+1 < len(source) && isDigit(source[i+1])):
+			start := i
+			dot := false
+			for i < len(source) && (isDigit(source[i]) || (source[i] == '.' && !dot)) {
+				dot = dot || source[i] == '.'
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, source[start:i], start})
+		case isLetter(c):
+			start := i
+			for i < len(source) && (isLetter(source[i]) || isDigit(source[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, source[start:i], start})
+		case c == '"':
+			start := i
+			end := strings.IndexByte(source[i+1:], '"')
+			if end < 0 {
+				return nil, newError(start, fmt.Errorf("%w: unterminated string", ErrSyntax))
+			}
+			i += end + 2
+			tokens = append(tokens, token{tokenString, source[start+1 : i-1], start})
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(source[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, newError(i, fmt.Errorf("%w: unexpected character %q", ErrSyntax, c))
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(source)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// node is a node of the abstract syntax tree.
+type node interface {
+	pos() int
+	String() string
+}
+
+type numberNode struct {
+	p     int
+	value *bcd.BCD
+}
+
+func (n *numberNode) pos() int       { return n.p }
+func (n *numberNode) String() string { return n.value.String() }
+
+type stringNode struct {
+	p     int
+	value string
+}
+
+func (n *stringNode) pos() int       { return n.p }
+func (n *stringNode) String() string { return strconv.Quote(n.value) }
+
+type varNode struct {
+	p    int
+	name string
+}
+
+func (n *varNode) pos() int       { return n.p }
+func (n *varNode) String() string { return n.name }
+
+type unaryNode struct {
+	p       int
+	op      string
+	operand node
+}
+
+func (n *unaryNode) pos() int       { return n.p }
+func (n *unaryNode) String() string { return "(" + n.op + n.operand.String() + ")" }
+
+type binaryNode struct {
+	p     int
+	op    string
+	left  node
+	right node
+}
+
+func (n *binaryNode) pos() int { return n.p }
+func (n *binaryNode) String() string {
+	return "(" + n.left.String() + " " + n.op + " " + n.right.String() + ")"
+}
+
+type callNode struct {
+	p    int
+	name string
+	args []node
+}
+
+func (n *callNode) pos() int { return n.p }
+func (n *callNode) String() string {
+	args := make([]string, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.String()
+	}
+	return n.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// walk calls fn for n and all its descendants.
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case *unaryNode:
+		walk(n.operand, fn)
+	case *binaryNode:
+		walk(n.left, fn)
+		walk(n.right, fn)
+	case *callNode:
+		for _, arg := range n.args {
+			walk(arg, fn)
+		}
+	}
+}
+
+// precedences of the binary operators, higher binds stronger.
+var precedences = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3,
+	"+": 4, "-": 4,
+	"*": 5, "/": 5, "%": 5,
+}
+
+// parser is a recursive descent parser for expressions.
+type parser struct {
+	tokens []token
+	idx    int
+}
+
+// newParser tokenizes the source and creates the parser.
+func newParser(source string) (*parser, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+// parse parses the whole source into one expression.
+func (p *parser) parse() (node, error) {
+	n, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return n, nil
+}
+
+// parseBinary parses binary operations with at least the given
+// precedence by precedence climbing. Comparisons do not chain.
+func (p *parser) parseBinary(minPrec int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec, ok := precedences[t.text]
+		if t.kind != tokenOperator || !ok || prec < minPrec {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{p: t.pos, op: t.text, left: left, right: right}
+		if prec == precedences["=="] {
+			if n := p.peek(); n.kind == tokenOperator && precedences[n.text] == prec {
+				return nil, newError(n.pos, fmt.Errorf("%w: comparisons cannot be chained", ErrSyntax))
+			}
+		}
+	}
+}
+
+// parseUnary parses the prefix operators - + and !.
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.kind == tokenOperator && (t.text == "-" || t.text == "+" || t.text == "!") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{p: t.pos, op: t.text, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses numbers, strings, variables, function calls,
+// and parenthesized expressions.
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, err := bcd.New(t.text)
+		if err != nil {
+			return nil, newError(t.pos, fmt.Errorf("%w: invalid number %q", ErrSyntax, t.text))
+		}
+		return &numberNode{p: t.pos, value: value}, nil
+	case tokenString:
+		return &stringNode{p: t.pos, value: t.text}, nil
+	case tokenIdent:
+		if n := p.peek(); n.kind == tokenOperator && n.text == "(" {
+			p.next()
+			return p.parseCall(t)
+		}
+		return &varNode{p: t.pos, name: t.text}, nil
+	case tokenOperator:
+		if t.text == "(" {
+			n, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	return nil, p.unexpected(t)
+}
+
+// parseCall parses the arguments of a function call after the
+// opening parenthesis.
+func (p *parser) parseCall(name token) (node, error) {
+	call := &callNode{p: name.pos, name: name.text}
+	if n := p.peek(); n.kind == tokenOperator && n.text == ")" {
+		p.next()
+		return call, nil
+	}
+	for {
+		arg, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		t := p.next()
+		if t.kind == tokenOperator && t.text == ")" {
+			return call, nil
+		}
+		if t.kind != tokenOperator || t.text != "," {
+			return nil, p.unexpected(t)
+		}
+	}
+}
+
+// expect consumes the given operator or returns a syntax error.
+func (p *parser) expect(op string) error {
+	t := p.next()
+	if t.kind != tokenOperator || t.text != op {
+		return newError(t.pos, fmt.Errorf("%w: expected %q", ErrSyntax, op))
+	}
+	return nil
+}
+
+// unexpected returns a syntax error for the token.
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return newError(t.pos, fmt.Errorf("%w: unexpected end of expression", ErrSyntax))
+	}
+	return newError(t.pos, fmt.Errorf("%w: unexpected %q", ErrSyntax, t.text))
+}
+
+// peek returns the current token without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.idx]
+}
+
+// next consumes the current token, the final EOF token is never consumed.
+func (p *parser) next() token {
+	t := p.tokens[p.idx]
+	if t.kind != tokenEOF {
+		p.idx++
+	}
+	return t
+}
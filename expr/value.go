@@ -0,0 +1,122 @@
+// Tideland Go BCD - Expressions
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package expr
+
+import (
+	"strconv"
+
+	"tideland.dev/go/bcd"
+)
+
+// Kind describes the type of a value.
+type Kind int
+
+// Value kinds. KindInvalid is the kind of the zero Value, returned by
+// Eval together with an error.
+const (
+	KindInvalid Kind = iota
+	KindNumber
+	KindAmount
+	KindBool
+	KindString
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindNumber:
+		return "number"
+	case KindAmount:
+		return "amount"
+	case KindBool:
+		return "bool"
+	case KindString:
+		return "string"
+	case KindInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// Value is the result of an evaluation or an argument of a function.
+type Value struct {
+	kind    Kind
+	number  *bcd.BCD
+	amount  *bcd.Amount
+	boolean bool
+	str     string
+}
+
+// NumberValue returns a number value.
+func NumberValue(b *bcd.BCD) Value {
+	return Value{kind: KindNumber, number: b}
+}
+
+// AmountValue returns an amount value.
+func AmountValue(a *bcd.Amount) Value {
+	return Value{kind: KindAmount, amount: a}
+}
+
+// BoolValue returns a boolean value.
+func BoolValue(b bool) Value {
+	return Value{kind: KindBool, boolean: b}
+}
+
+// StringValue returns a string value.
+func StringValue(s string) Value {
+	return Value{kind: KindString, str: s}
+}
+
+// Kind returns the kind of the value.
+func (v Value) Kind() Kind {
+	return v.kind
+}
+
+// Number returns the number of a number value or the plain number of
+// an amount value, otherwise nil.
+func (v Value) Number() *bcd.BCD {
+	switch v.kind {
+	case KindNumber:
+		return v.number
+	case KindAmount:
+		return v.amount.Amount()
+	default:
+		return nil
+	}
+}
+
+// Amount returns the amount of an amount value, otherwise nil.
+func (v Value) Amount() *bcd.Amount {
+	return v.amount
+}
+
+// Bool returns the boolean of a bool value, otherwise false.
+func (v Value) Bool() bool {
+	return v.boolean
+}
+
+// String returns the value as string, amounts with their currency code
+// like "12.50 EUR". Invalid values return an empty string.
+func (v Value) String() string {
+	switch v.kind {
+	case KindNumber:
+		return v.number.String()
+	case KindAmount:
+		return v.amount.Format(false, true)
+	case KindBool:
+		return strconv.FormatBool(v.boolean)
+	default:
+		return v.str
+	}
+}
+
+// isNumeric returns true for numbers and amounts.
+func (v Value) isNumeric() bool {
+	return v.kind == KindNumber || v.kind == KindAmount
+}
@@ -0,0 +1,211 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignStyle defines how fixed-width fields carry the sign.
+type SignStyle int
+
+// Sign styles of fixed-width fields.
+const (
+	SignNone      SignStyle = iota // Unsigned digits only
+	SignLeading                    // Leading '+' or '-'
+	SignTrailing                   // Trailing '+' or '-'
+	SignOverpunch                  // Last digit overpunched like COBOL zoned decimals
+)
+
+// Overpunched characters of the last digit for positive and negative
+// values, indexed by the digit.
+const (
+	overpunchPositive = "{ABCDEFGHI"
+	overpunchNegative = "}JKLMNOPQR"
+)
+
+// FormatFixedWidth returns the value as field of width characters with
+// an implied decimal point, e.g. 123.45 with scale 2 as "0000012345" for
+// width 10. Leading and trailing signs take one of the characters. Values
+// not fitting into the width return ErrOverflow, values with more
+// fractional digits than the scale ErrPrecisionLoss, and negative values
+// with SignNone ErrInvalidOperation.
+func (b *BCD) FormatFixedWidth(width, scale int, style SignStyle) (string, error) {
+	if !b.IsFinite() {
+		return "", fmt.Errorf("%w: %s has no fixed-width form", ErrInvalidOperation, b)
+	}
+	if !b.Round(scale, RoundDown).Equal(b) {
+		return "", fmt.Errorf("%w: %s has more than %d decimal places", ErrPrecisionLoss, b, scale)
+	}
+	precision := width
+	if style == SignLeading || style == SignTrailing {
+		precision--
+	}
+	digits, negative, err := b.unscaledDigits(precision, scale, RoundDown)
+	if err != nil {
+		return "", err
+	}
+	if negative && style == SignNone {
+		return "", fmt.Errorf("%w: %s needs a signed field", ErrInvalidOperation, b)
+	}
+
+	buf := make([]byte, 0, width)
+	if style == SignLeading {
+		buf = append(buf, signChar(negative))
+	}
+	for i := precision - 1; i >= 0; i-- {
+		d := byte(0)
+		if i < len(digits) {
+			d = digits[i]
+		}
+		buf = append(buf, '0'+d)
+	}
+	switch style {
+	case SignTrailing:
+		buf = append(buf, signChar(negative))
+	case SignOverpunch:
+		last := len(buf) - 1
+		if negative {
+			buf[last] = overpunchNegative[buf[last]-'0']
+		} else {
+			buf[last] = overpunchPositive[buf[last]-'0']
+		}
+	}
+	return string(buf), nil
+}
+
+// ParseFixedWidth parses a fixed-width field with an implied decimal
+// point and the given sign style. Overpunched fields also accept a plain
+// last digit as positive.
+func ParseFixedWidth(s string, scale int, style SignStyle) (*BCD, error) {
+	if scale < 0 {
+		return nil, fmt.Errorf("%w: negative scale %d", ErrInvalidOperation, scale)
+	}
+	field := s
+	negative := false
+	switch style {
+	case SignLeading:
+		if field == "" {
+			break
+		}
+		negative = field[0] == '-'
+		if field[0] != '-' && field[0] != '+' {
+			return nil, fmt.Errorf("%w: missing leading sign in %q", ErrInvalidFormat, s)
+		}
+		field = field[1:]
+	case SignTrailing:
+		if field == "" {
+			break
+		}
+		last := field[len(field)-1]
+		negative = last == '-'
+		if last != '-' && last != '+' {
+			return nil, fmt.Errorf("%w: missing trailing sign in %q", ErrInvalidFormat, s)
+		}
+		field = field[:len(field)-1]
+	case SignOverpunch:
+		if field == "" {
+			break
+		}
+		last := field[len(field)-1]
+		if i := strings.IndexByte(overpunchPositive, last); i >= 0 {
+			field = field[:len(field)-1] + string(rune('0'+i))
+		} else if i := strings.IndexByte(overpunchNegative, last); i >= 0 {
+			field = field[:len(field)-1] + string(rune('0'+i))
+			negative = true
+		}
+	}
+	if field == "" {
+		return nil, fmt.Errorf("%w: empty fixed-width field %q", ErrInvalidFormat, s)
+	}
+	digits := make([]uint8, len(field))
+	for i := range len(field) {
+		c := field[len(field)-1-i]
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("%w: invalid character %q in %q", ErrInvalidFormat, c, s)
+		}
+		digits[i] = c - '0'
+	}
+	return fromUnscaledDigits(digits, negative, scale), nil
+}
+
+// FormatFixedWidth returns the amount as fixed-width field like the BCD
+// method. The scale is usually the number of decimal places of the
+// currency.
+func (c *Amount) FormatFixedWidth(width, scale int, style SignStyle) (string, error) {
+	if c == nil || c.amount == nil {
+		return "", ErrInvalidAmount
+	}
+	return c.amount.FormatFixedWidth(width, scale, style)
+}
+
+// ParseAmountFixedWidth parses a fixed-width field as amount of the
+// currency. Digits beyond the decimal places of the currency return
+// ErrPrecisionLoss.
+func ParseAmountFixedWidth(s, code string, scale int, style SignStyle) (*Amount, error) {
+	value, err := ParseFixedWidth(s, scale, style)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	a, err := NewAmount(value, code)
+	if err != nil {
+		return nil, err
+	}
+	if !a.amount.Equal(value) {
+		return nil, fmt.Errorf("%w: %q exceeds the %d decimal places of %s",
+			ErrPrecisionLoss, s, a.info.DecimalPlaces, a.info.Code)
+	}
+	return a, nil
+}
+
+// ControlTotal parses the fixed-width fields and returns their sum, e.g.
+// to verify the total of a batch trailer.
+func ControlTotal(fields []string, scale int, style SignStyle) (*BCD, error) {
+	sum := NewSum()
+	for i, field := range fields {
+		value, err := ParseFixedWidth(field, scale, style)
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", i, err)
+		}
+		sum.Add(value)
+	}
+	return sum.Result(), nil
+}
+
+// HashTotal returns the sum of the unsigned digit fields as integers,
+// keeping only the rightmost width digits like the entry hash of NACHA
+// batches. Different to FormatFixedWidth the truncation is intended
+// here, so it does not return ErrOverflow.
+func HashTotal(fields []string, width int) (string, error) {
+	if width < 1 {
+		return "", fmt.Errorf("%w: invalid hash width %d", ErrInvalidOperation, width)
+	}
+	total, err := ControlTotal(fields, 0, SignNone)
+	if err != nil {
+		return "", err
+	}
+	digits := total.digits
+	buf := make([]byte, width)
+	for i := range width {
+		d := byte(0)
+		if i < len(digits) {
+			d = digits[i]
+		}
+		buf[width-1-i] = '0' + d
+	}
+	return string(buf), nil
+}
+
+// signChar returns the sign character of a leading or trailing sign.
+func signChar(negative bool) byte {
+	if negative {
+		return '-'
+	}
+	return '+'
+}
@@ -0,0 +1,145 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestFixedWidth(t *testing.T) {
+	tests := []struct {
+		value string
+		width int
+		scale int
+		style SignStyle
+		field string
+	}{
+		{"123.45", 10, 2, SignNone, "0000012345"},
+		{"0", 4, 2, SignNone, "0000"},
+		{"7", 6, 0, SignNone, "000007"},
+		{"123.4", 8, 3, SignNone, "00123400"},
+		{"123.45", 10, 2, SignLeading, "+000012345"},
+		{"-123.45", 10, 2, SignLeading, "-000012345"},
+		{"123.45", 10, 2, SignTrailing, "000012345+"},
+		{"-123.45", 10, 2, SignTrailing, "000012345-"},
+		{"123.45", 8, 2, SignOverpunch, "0001234E"},
+		{"-123.45", 8, 2, SignOverpunch, "0001234N"},
+		{"123.40", 8, 2, SignOverpunch, "0001234{"},
+		{"-123.40", 8, 2, SignOverpunch, "0001234}"},
+		{"-0.01", 4, 2, SignOverpunch, "000J"},
+		{"-0.09", 4, 2, SignOverpunch, "000R"},
+		{"99999.99", 7, 2, SignOverpunch, "999999I"},
+	}
+	for _, test := range tests {
+		field, err := Must(test.value).FormatFixedWidth(test.width, test.scale, test.style)
+		verify.NoError(t, err)
+		verify.Equal(t, field, test.field, test.value)
+
+		back, err := ParseFixedWidth(field, test.scale, test.style)
+		verify.NoError(t, err)
+		verify.True(t, back.Equal(Must(test.value)), test.field)
+		verify.Equal(t, back.Scale(), test.scale)
+	}
+}
+
+func TestParseFixedWidth(t *testing.T) {
+	tests := []struct {
+		field string
+		scale int
+		style SignStyle
+		value string
+	}{
+		{"0000012345", 2, SignNone, "123.45"},
+		{"0000012345", 0, SignNone, "12345"},
+		{"0000012345", 5, SignOverpunch, "0.12345"},
+		{"-000000000", 2, SignLeading, "0"},
+	}
+	for _, test := range tests {
+		value, err := ParseFixedWidth(test.field, test.scale, test.style)
+		verify.NoError(t, err)
+		verify.True(t, value.Equal(Must(test.value)), test.field)
+		verify.True(t, !value.IsNegative(), test.field)
+	}
+
+	invalid := []struct {
+		field string
+		style SignStyle
+	}{
+		{"", SignNone},
+		{"00012 45", SignNone},
+		{"-0012345", SignNone},
+		{"00012345", SignLeading},
+		{"00012345", SignTrailing},
+		{"+", SignLeading},
+		{"00012S", SignOverpunch},
+	}
+	for _, test := range invalid {
+		_, err := ParseFixedWidth(test.field, 2, test.style)
+		verify.IsError(t, err, ErrInvalidFormat)
+	}
+}
+
+func TestFixedWidthErrors(t *testing.T) {
+	_, err := Must("1234.56").FormatFixedWidth(5, 2, SignNone)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("-123.45").FormatFixedWidth(5, 2, SignTrailing)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("1.234").FormatFixedWidth(10, 2, SignNone)
+	verify.IsError(t, err, ErrPrecisionLoss)
+	_, err = Must("-1").FormatFixedWidth(10, 2, SignNone)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Inf(1).FormatFixedWidth(10, 2, SignNone)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestAmountFixedWidth(t *testing.T) {
+	a, err := NewAmount("-1234.56", "USD")
+	verify.NoError(t, err)
+	field, err := a.FormatFixedWidth(12, 2, SignTrailing)
+	verify.NoError(t, err)
+	verify.Equal(t, field, "00000123456-")
+
+	back, err := ParseAmountFixedWidth(field, "usd", 2, SignTrailing)
+	verify.NoError(t, err)
+	verify.True(t, back.Equal(a))
+
+	_, err = ParseAmountFixedWidth("00012345", "JPY", 2, SignNone)
+	verify.IsError(t, err, ErrPrecisionLoss)
+	_, err = ParseAmountFixedWidth("00012300", "JPY", 2, SignNone)
+	verify.NoError(t, err)
+	_, err = ParseAmountFixedWidth("0001234X", "EUR", 2, SignNone)
+	verify.IsError(t, err, ErrInvalidAmount)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = ParseAmountFixedWidth("00012345", "XYZ", 2, SignNone)
+	verify.IsError(t, err, ErrUnknownCurrency)
+}
+
+func TestBatchTotals(t *testing.T) {
+	total, err := ControlTotal([]string{"0000010000", "0000002550", "000000125}"}, 2, SignOverpunch)
+	verify.NoError(t, err)
+	verify.Equal(t, total.String(), "113.00")
+
+	_, err = ControlTotal([]string{"0000010000", "00000x2550"}, 2, SignNone)
+	verify.IsError(t, err, ErrInvalidFormat)
+
+	// Entry hash of the first eight digits of the routing numbers
+	hash, err := HashTotal([]string{"09100001", "02100002", "07100005"}, 10)
+	verify.NoError(t, err)
+	verify.Equal(t, hash, "0018300008")
+
+	hash, err = HashTotal([]string{"99999999", "99999999"}, 4)
+	verify.NoError(t, err)
+	verify.Equal(t, hash, "9998")
+
+	_, err = HashTotal([]string{"-1"}, 4)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = HashTotal(nil, 0)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
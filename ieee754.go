@@ -0,0 +1,488 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// decimalFormat describes an IEEE 754-2008 decimal interchange format.
+type decimalFormat struct {
+	width     int // total number of bits
+	precision int // number of coefficient digits
+	expCont   int // bits of the exponent continuation field in DPD
+	bias      int // exponent bias
+}
+
+var (
+	decimal64  = decimalFormat{width: 64, precision: 16, expCont: 8, bias: 398}
+	decimal128 = decimalFormat{width: 128, precision: 34, expCont: 12, bias: 6176}
+)
+
+// expBits returns the number of bits of the biased exponent.
+func (f decimalFormat) expBits() int {
+	return f.expCont + 2
+}
+
+// qMin returns the smallest exponent of the format.
+func (f decimalFormat) qMin() int {
+	return -f.bias
+}
+
+// qMax returns the largest exponent of the format.
+func (f decimalFormat) qMax() int {
+	return (3<<f.expCont - 1) - f.bias
+}
+
+// uint128 is a 128 bit value for the bit fiddling of the encodings.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// field returns width bits of u starting at bit pos.
+func (u uint128) field(pos, width int) uint64 {
+	v := u
+	if pos >= 64 {
+		v = uint128{lo: u.hi >> (pos - 64)}
+	} else if pos > 0 {
+		v = uint128{hi: u.hi >> pos, lo: u.lo>>pos | u.hi<<(64-pos)}
+	}
+	return v.lo & (1<<width - 1)
+}
+
+// setField sets width bits of u starting at bit pos to val.
+func (u *uint128) setField(pos, width int, val uint64) {
+	val &= 1<<width - 1
+	if pos >= 64 {
+		u.hi |= val << (pos - 64)
+		return
+	}
+	u.lo |= val << pos
+	if pos+width > 64 {
+		u.hi |= val >> (64 - pos)
+	}
+}
+
+// ToDecimal64BID encodes the BCD as IEEE 754 decimal64 with binary integer
+// decimal coefficient (BID), most significant byte first. Coefficients
+// with more than 16 digits or exponents outside of the format are rounded
+// with the given mode, values too large for the format return ErrOverflow.
+func (b *BCD) ToDecimal64BID(mode RoundingMode) ([8]byte, error) {
+	var data [8]byte
+	u, err := b.encodeBID(decimal64, mode)
+	if err != nil {
+		return data, err
+	}
+	binary.BigEndian.PutUint64(data[:], u.lo)
+	return data, nil
+}
+
+// FromDecimal64BID decodes an IEEE 754 decimal64 in BID encoding, most
+// significant byte first.
+func FromDecimal64BID(data [8]byte) *BCD {
+	return decodeBID(decimal64, uint128{lo: binary.BigEndian.Uint64(data[:])})
+}
+
+// ToDecimal128BID encodes the BCD as IEEE 754 decimal128 with binary integer
+// decimal coefficient (BID) like used by MongoDB, most significant byte
+// first. Coefficients with more than 34 digits or exponents outside of the
+// format are rounded with the given mode, values too large for the format
+// return ErrOverflow.
+func (b *BCD) ToDecimal128BID(mode RoundingMode) ([16]byte, error) {
+	var data [16]byte
+	u, err := b.encodeBID(decimal128, mode)
+	if err != nil {
+		return data, err
+	}
+	binary.BigEndian.PutUint64(data[:8], u.hi)
+	binary.BigEndian.PutUint64(data[8:], u.lo)
+	return data, nil
+}
+
+// FromDecimal128BID decodes an IEEE 754 decimal128 in BID encoding, most
+// significant byte first. MongoDB stores the bytes in reverse order.
+func FromDecimal128BID(data [16]byte) *BCD {
+	return decodeBID(decimal128, uint128{
+		hi: binary.BigEndian.Uint64(data[:8]),
+		lo: binary.BigEndian.Uint64(data[8:]),
+	})
+}
+
+// ToDecimal64DPD encodes the BCD as IEEE 754 decimal64 with densely packed
+// decimal coefficient (DPD), most significant byte first. Rounding and
+// overflow are handled like by ToDecimal64BID.
+func (b *BCD) ToDecimal64DPD(mode RoundingMode) ([8]byte, error) {
+	var data [8]byte
+	u, err := b.encodeDPD(decimal64, mode)
+	if err != nil {
+		return data, err
+	}
+	binary.BigEndian.PutUint64(data[:], u.lo)
+	return data, nil
+}
+
+// FromDecimal64DPD decodes an IEEE 754 decimal64 in DPD encoding, most
+// significant byte first.
+func FromDecimal64DPD(data [8]byte) *BCD {
+	return decodeDPD(decimal64, uint128{lo: binary.BigEndian.Uint64(data[:])})
+}
+
+// ToDecimal128DPD encodes the BCD as IEEE 754 decimal128 with densely packed
+// decimal coefficient (DPD), most significant byte first. Rounding and
+// overflow are handled like by ToDecimal128BID.
+func (b *BCD) ToDecimal128DPD(mode RoundingMode) ([16]byte, error) {
+	var data [16]byte
+	u, err := b.encodeDPD(decimal128, mode)
+	if err != nil {
+		return data, err
+	}
+	binary.BigEndian.PutUint64(data[:8], u.hi)
+	binary.BigEndian.PutUint64(data[8:], u.lo)
+	return data, nil
+}
+
+// FromDecimal128DPD decodes an IEEE 754 decimal128 in DPD encoding, most
+// significant byte first.
+func FromDecimal128DPD(data [16]byte) *BCD {
+	return decodeDPD(decimal128, uint128{
+		hi: binary.BigEndian.Uint64(data[:8]),
+		lo: binary.BigEndian.Uint64(data[8:]),
+	})
+}
+
+// encodeSpecial encodes NaNs and infinities, they are identical for BID
+// and DPD. The second result is false for finite values.
+func (b *BCD) encodeSpecial(f decimalFormat) (uint128, bool) {
+	var u uint128
+	if b.negative {
+		u.setField(f.width-1, 1, 1)
+	}
+	switch b.form {
+	case infinite:
+		u.setField(f.width-6, 5, 0b11110)
+	case quietNaN:
+		u.setField(f.width-6, 5, 0b11111)
+	case signalingNaN:
+		u.setField(f.width-7, 6, 0b111111)
+	default:
+		return u, false
+	}
+	return u, true
+}
+
+// fitCoefficient returns the coefficient digits (little-endian) and the
+// exponent of b fitted into the format, rounded if needed.
+func (b *BCD) fitCoefficient(f decimalFormat, mode RoundingMode) ([]uint8, int, error) {
+	digits := b.digits
+	q := -b.scale
+
+	// dropDigits rounds away the n least significant digits
+	dropDigits := func(n int) {
+		rounded := (&BCD{digits: digits, scale: n, negative: b.negative}).round(0, mode, false)
+		digits = rounded.digits
+		q += n
+	}
+
+	// Remove leading zeros of the coefficient
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	if len(digits) > f.precision {
+		dropDigits(len(digits) - f.precision)
+	}
+	if q < f.qMin() {
+		dropDigits(f.qMin() - q)
+	}
+	if len(digits) > f.precision {
+		// Rounding carried into a new digit, 10^p is still exact
+		digits = digits[1:]
+		q++
+	}
+
+	if q > f.qMax() {
+		// Clamp by adding zeros to the coefficient if there is room
+		if isZero(digits) {
+			q = f.qMax()
+		} else {
+			pad := q - f.qMax()
+			if len(digits)+pad > f.precision {
+				return nil, 0, ErrOverflow
+			}
+			digits = append(make([]uint8, pad), digits...)
+			q = f.qMax()
+		}
+	}
+
+	return digits, q, nil
+}
+
+// encodeBID encodes b in the BID format f.
+func (b *BCD) encodeBID(f decimalFormat, mode RoundingMode) (uint128, error) {
+	if u, ok := b.encodeSpecial(f); ok {
+		return u, nil
+	}
+	digits, q, err := b.fitCoefficient(f, mode)
+	if err != nil {
+		return uint128{}, err
+	}
+
+	// Convert the coefficient to binary
+	var c uint128
+	for i := len(digits) - 1; i >= 0; i-- {
+		c = c.mulAdd(10, uint64(digits[i]))
+	}
+
+	var u uint128
+	if b.negative {
+		u.setField(f.width-1, 1, 1)
+	}
+	e := uint64(q + f.bias)
+	coeffBits := f.width - 1 - f.expBits()
+	if c.field(coeffBits, 64) == 0 {
+		// Exponent follows the sign, coefficient fills the rest
+		u.setField(coeffBits, f.expBits(), e)
+		u.setField(0, min(coeffBits, 64), c.lo)
+		if coeffBits > 64 {
+			u.setField(64, coeffBits-64, c.hi)
+		}
+	} else {
+		// Large coefficients start with the implicit bits 100
+		u.setField(f.width-3, 2, 0b11)
+		u.setField(coeffBits-2, f.expBits(), e)
+		u.setField(0, coeffBits-2, c.lo)
+	}
+	return u, nil
+}
+
+// decodeBID decodes u in the BID format f.
+func decodeBID(f decimalFormat, u uint128) *BCD {
+	negative := u.field(f.width-1, 1) == 1
+	if special := decodeSpecial(f, u, negative); special != nil {
+		return special
+	}
+
+	var e uint64
+	var c uint128
+	coeffBits := f.width - 1 - f.expBits()
+	if u.field(f.width-3, 2) == 0b11 {
+		// Large coefficients start with the implicit bits 100, in
+		// decimal128 they always exceed the precision
+		e = u.field(coeffBits-2, f.expBits())
+		if coeffBits-2 < 64 {
+			c.lo = u.field(0, coeffBits-2) | 0b100<<(coeffBits-2)
+		} else {
+			c.hi = 1 << 63
+		}
+	} else {
+		e = u.field(coeffBits, f.expBits())
+		c.lo = u.field(0, min(coeffBits, 64))
+		if coeffBits > 64 {
+			c.hi = u.field(64, coeffBits-64)
+		}
+	}
+
+	// Convert the coefficient to decimal digits, non-canonical
+	// coefficients beyond the precision are zero
+	var digits []uint8
+	for c.hi != 0 || c.lo != 0 {
+		var d uint64
+		c, d = c.divMod(10)
+		digits = append(digits, uint8(d))
+	}
+	if len(digits) > f.precision {
+		digits = nil
+	}
+
+	return fromCoefficient(digits, int(e)-f.bias, negative)
+}
+
+// encodeDPD encodes b in the DPD format f.
+func (b *BCD) encodeDPD(f decimalFormat, mode RoundingMode) (uint128, error) {
+	if u, ok := b.encodeSpecial(f); ok {
+		return u, nil
+	}
+	digits, q, err := b.fitCoefficient(f, mode)
+	if err != nil {
+		return uint128{}, err
+	}
+	digit := func(i int) uint64 {
+		if i < len(digits) {
+			return uint64(digits[i])
+		}
+		return 0
+	}
+
+	var u uint128
+	if b.negative {
+		u.setField(f.width-1, 1, 1)
+	}
+
+	// Combination field with the exponent's leading bits and the
+	// most significant digit, followed by the exponent continuation
+	e := uint64(q + f.bias)
+	eHigh := e >> f.expCont
+	msd := digit(f.precision - 1)
+	comb := eHigh<<3 | msd
+	if msd >= 8 {
+		comb = 0b11000 | eHigh<<1 | msd&1
+	}
+	u.setField(f.width-6, 5, comb)
+	u.setField(f.width-6-f.expCont, f.expCont, e)
+
+	// Declets of the remaining digits
+	for i := 0; i < (f.precision-1)/3; i++ {
+		declet := encodeDeclet(digit(3*i+2), digit(3*i+1), digit(3*i))
+		u.setField(10*i, 10, declet)
+	}
+	return u, nil
+}
+
+// decodeDPD decodes u in the DPD format f.
+func decodeDPD(f decimalFormat, u uint128) *BCD {
+	negative := u.field(f.width-1, 1) == 1
+	if special := decodeSpecial(f, u, negative); special != nil {
+		return special
+	}
+
+	comb := u.field(f.width-6, 5)
+	var eHigh, msd uint64
+	if comb>>3 == 0b11 {
+		eHigh = comb >> 1 & 0b11
+		msd = 8 + comb&1
+	} else {
+		eHigh = comb >> 3
+		msd = comb & 0b111
+	}
+	e := eHigh<<f.expCont | u.field(f.width-6-f.expCont, f.expCont)
+
+	digits := make([]uint8, f.precision)
+	digits[f.precision-1] = uint8(msd)
+	for i := 0; i < (f.precision-1)/3; i++ {
+		d2, d1, d0 := decodeDeclet(u.field(10*i, 10))
+		digits[3*i+2], digits[3*i+1], digits[3*i] = uint8(d2), uint8(d1), uint8(d0)
+	}
+
+	return fromCoefficient(digits, int(e)-f.bias, negative)
+}
+
+// decodeSpecial decodes NaNs and infinities, nil is returned for
+// finite values.
+func decodeSpecial(f decimalFormat, u uint128, negative bool) *BCD {
+	var b *BCD
+	switch {
+	case u.field(f.width-7, 6) == 0b111111:
+		b = SignalingNaN()
+	case u.field(f.width-6, 5) == 0b11111:
+		b = NaN()
+	case u.field(f.width-6, 5) == 0b11110:
+		b = Inf(1)
+	default:
+		return nil
+	}
+	b.negative = negative
+	return b
+}
+
+// fromCoefficient creates a BCD from little-endian coefficient digits
+// and an exponent. The number of digits after the decimal point is kept.
+func fromCoefficient(digits []uint8, q int, negative bool) *BCD {
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) == 0 {
+		digits = []uint8{0}
+	}
+	b := &BCD{digits: digits, negative: negative}
+	if q < 0 {
+		b.scale = -q
+	} else if q > 0 && !isZero(digits) {
+		b.digits = append(make([]uint8, q), digits...)
+	}
+	return b
+}
+
+// encodeDeclet packs three decimal digits into ten bits of densely
+// packed decimal.
+func encodeDeclet(d2, d1, d0 uint64) uint64 {
+	a, e, i := d2>>3, d1>>3, d0>>3
+	b, c, d := d2>>2&1, d2>>1&1, d2&1
+	f, g, h := d1>>2&1, d1>>1&1, d1&1
+	j, k, m := d0>>2&1, d0>>1&1, d0&1
+
+	pack := func(p, q, r, s, t, u, v, w, x, y uint64) uint64 {
+		return p<<9 | q<<8 | r<<7 | s<<6 | t<<5 | u<<4 | v<<3 | w<<2 | x<<1 | y
+	}
+
+	switch a<<2 | e<<1 | i {
+	case 0b000:
+		return pack(b, c, d, f, g, h, 0, j, k, m)
+	case 0b001:
+		return pack(b, c, d, f, g, h, 1, 0, 0, m)
+	case 0b010:
+		return pack(b, c, d, j, k, h, 1, 0, 1, m)
+	case 0b100:
+		return pack(j, k, d, f, g, h, 1, 1, 0, m)
+	case 0b110:
+		return pack(j, k, d, 0, 0, h, 1, 1, 1, m)
+	case 0b101:
+		return pack(f, g, d, 0, 1, h, 1, 1, 1, m)
+	case 0b011:
+		return pack(b, c, d, 1, 0, h, 1, 1, 1, m)
+	default:
+		return pack(0, 0, d, 1, 1, h, 1, 1, 1, m)
+	}
+}
+
+// decodeDeclet unpacks ten bits of densely packed decimal into three
+// decimal digits. Non-canonical declets are decoded too.
+func decodeDeclet(declet uint64) (uint64, uint64, uint64) {
+	bit := func(n uint) uint64 { return declet >> n & 1 }
+	p, q, r := bit(9), bit(8), bit(7)
+	s, t, u := bit(6), bit(5), bit(4)
+	v, w, x, y := bit(3), bit(2), bit(1), bit(0)
+
+	digit := func(b2, b1, b0 uint64) uint64 { return b2<<2 | b1<<1 | b0 }
+
+	if v == 0 {
+		return digit(p, q, r), digit(s, t, u), digit(w, x, y)
+	}
+	switch w<<1 | x {
+	case 0b00:
+		return digit(p, q, r), digit(s, t, u), 8 + y
+	case 0b01:
+		return digit(p, q, r), 8 + u, digit(s, t, y)
+	case 0b10:
+		return 8 + r, digit(s, t, u), digit(p, q, y)
+	}
+	switch s<<1 | t {
+	case 0b00:
+		return 8 + r, 8 + u, digit(p, q, y)
+	case 0b01:
+		return 8 + r, digit(p, q, u), 8 + y
+	case 0b10:
+		return digit(p, q, r), 8 + u, 8 + y
+	default:
+		return 8 + r, 8 + u, 8 + y
+	}
+}
+
+// mulAdd returns u * m + a.
+func (u uint128) mulAdd(m, a uint64) uint128 {
+	hi, lo := bits.Mul64(u.lo, m)
+	lo, carry := bits.Add64(lo, a, 0)
+	return uint128{hi: u.hi*m + hi + carry, lo: lo}
+}
+
+// divMod returns u / d and u % d.
+func (u uint128) divMod(d uint64) (uint128, uint64) {
+	qhi, r := bits.Div64(0, u.hi, d)
+	qlo, r := bits.Div64(r, u.lo, d)
+	return uint128{hi: qhi, lo: qlo}, r
+}
@@ -0,0 +1,187 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// reversed returns the bytes in reverse order, e.g. to compare with
+// the little-endian vectors of the BSON corpus.
+func reversed(data []byte) []byte {
+	r := make([]byte, len(data))
+	for i, b := range data {
+		r[len(data)-1-i] = b
+	}
+	return r
+}
+
+func TestDecimal128BID(t *testing.T) {
+	// Vectors of the BSON corpus for Decimal128, little-endian.
+	tests := []struct {
+		value string
+		bson  string
+		back  string
+	}{
+		{"1", "01000000000000000000000000004030", "1"},
+		{"-1", "010000000000000000000000000040B0", "-1"},
+		{"0", "00000000000000000000000000004030", "0"},
+		{"-0", "000000000000000000000000000040B0", "-0"},
+		{"0.1", "01000000000000000000000000003E30", "0.1"},
+		{"1234567890123456789012345678901234", "F2AF967ED05C82DE3297FF6FDE3C4030", "1234567890123456789012345678901234"},
+		{"NaN", "0000000000000000000000000000007C", "NaN"},
+		{"Inf", "00000000000000000000000000000078", "Inf"},
+		{"-Inf", "000000000000000000000000000000F8", "-Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			data, err := Must(tt.value).ToDecimal128BID(RoundHalfEven)
+			verify.NoError(t, err)
+			verify.Equal(t, hex.EncodeToString(reversed(data[:])), strings.ToLower(tt.bson))
+
+			back := FromDecimal128BID(data)
+			verify.Equal(t, back.String(), tt.back)
+		})
+	}
+
+	// Exponents beyond the format are clamped by padding the coefficient
+	big := Must("1" + strings.Repeat("0", 6144))
+	data, err := big.ToDecimal128BID(RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data[:]), "5ffe314dc6448d9338c15b0a00000000")
+	verify.True(t, FromDecimal128BID(data).Equal(big))
+
+	// Too large values overflow
+	_, err = Must("1" + strings.Repeat("0", 6145)).ToDecimal128BID(RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+}
+
+func TestDecimal64BID(t *testing.T) {
+	tests := []struct {
+		value string
+		bid   string
+		back  string
+	}{
+		{"1", "31c0000000000001", "1"},
+		{"-7.5", "b1a000000000004b", "-7.5"},
+		{"9999999999999999", "6c7386f26fc0ffff", "9999999999999999"},
+		{"-Inf", "f800000000000000", "-Inf"},
+		{"sNaN", "7e00000000000000", "sNaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			data, err := Must(tt.value).ToDecimal64BID(RoundHalfEven)
+			verify.NoError(t, err)
+			verify.Equal(t, hex.EncodeToString(data[:]), tt.bid)
+
+			back := FromDecimal64BID(data)
+			verify.Equal(t, back.String(), tt.back)
+		})
+	}
+}
+
+func TestDecimalDPD(t *testing.T) {
+	tests := []struct {
+		value  string
+		dpd64  string
+		dpd128 string
+	}{
+		{"1", "2238000000000001", "22080000000000000000000000000001"},
+		{"-7.5", "a234000000000075", "a207c000000000000000000000000075"},
+		{"0", "2238000000000000", "22080000000000000000000000000000"},
+		{"Inf", "7800000000000000", "78000000000000000000000000000000"},
+		{"-NaN", "fc00000000000000", "fc000000000000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			data64, err := Must(tt.value).ToDecimal64DPD(RoundHalfEven)
+			verify.NoError(t, err)
+			verify.Equal(t, hex.EncodeToString(data64[:]), tt.dpd64)
+			verify.Equal(t, FromDecimal64DPD(data64).String(), Must(tt.value).String())
+
+			data128, err := Must(tt.value).ToDecimal128DPD(RoundHalfEven)
+			verify.NoError(t, err)
+			verify.Equal(t, hex.EncodeToString(data128[:]), tt.dpd128)
+			verify.Equal(t, FromDecimal128DPD(data128).String(), Must(tt.value).String())
+		})
+	}
+
+	// Trailing zeros of the scale are kept
+	v := &BCD{digits: []uint8{0, 5, 7}, scale: 2, negative: true}
+	data64, err := v.ToDecimal64DPD(RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data64[:]), "a2300000000003d0")
+	verify.Equal(t, FromDecimal64DPD(data64).String(), "-7.50")
+	data128, err := v.ToDecimal128DPD(RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data128[:]), "a20780000000000000000000000003d0")
+	verify.Equal(t, FromDecimal128DPD(data128).String(), "-7.50")
+
+	// Coefficients with a large most significant digit and all declets
+	values := []string{"9876543210987654", "-1234567890.123456", "0.000000000000000000000000000000000001"}
+	for _, s := range values {
+		data64, err = Must(s).ToDecimal64DPD(RoundHalfEven)
+		verify.NoError(t, err)
+		verify.True(t, FromDecimal64DPD(data64).Equal(Must(s)))
+
+		data128, err = Must(s).ToDecimal128DPD(RoundHalfEven)
+		verify.NoError(t, err)
+		verify.True(t, FromDecimal128DPD(data128).Equal(Must(s)))
+	}
+}
+
+func TestDecimalDeclets(t *testing.T) {
+	// Spot checks of the densely packed decimal table
+	verify.Equal(t, encodeDeclet(0, 0, 5), uint64(0x005))
+	verify.Equal(t, encodeDeclet(0, 0, 9), uint64(0x009))
+	verify.Equal(t, encodeDeclet(0, 8, 0), uint64(0x00a))
+	verify.Equal(t, encodeDeclet(7, 5, 0), uint64(0x3d0))
+	verify.Equal(t, encodeDeclet(9, 9, 9), uint64(0x0ff))
+
+	// All 1000 combinations round trip
+	for n := uint64(0); n < 1000; n++ {
+		d2, d1, d0 := decodeDeclet(encodeDeclet(n/100, n/10%10, n%10))
+		verify.Equal(t, d2*100+d1*10+d0, n)
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	// 17 significant digits must be rounded to 16
+	v := Must("12345678901234565")
+	data, err := v.ToDecimal64BID(RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, FromDecimal64BID(data).String(), "12345678901234560")
+	data, err = v.ToDecimal64BID(RoundUp)
+	verify.NoError(t, err)
+	verify.Equal(t, FromDecimal64BID(data).String(), "12345678901234570")
+
+	// Rounding may carry into a new digit
+	data, err = Must("99999999999999999").ToDecimal64DPD(RoundHalfUp)
+	verify.NoError(t, err)
+	verify.Equal(t, FromDecimal64DPD(data).String(), "100000000000000000")
+
+	// Fractions are limited by the precision too
+	data, err = Must("0.12345678901234567").ToDecimal64BID(RoundDown)
+	verify.NoError(t, err)
+	verify.Equal(t, FromDecimal64BID(data).String(), "0.1234567890123456")
+
+	// Tiny values underflow to zero keeping the sign
+	data, err = Must("-0." + strings.Repeat("0", 400) + "1").ToDecimal64BID(RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, FromDecimal64BID(data).String(), "-0")
+
+	_, err = Must("1" + strings.Repeat("0", 385)).ToDecimal64BID(RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+}
@@ -0,0 +1,318 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"fmt"
+)
+
+// ErrInvalidInterval is returned for intervals with a lower bound
+// above the upper bound or with a NaN bound.
+var ErrInvalidInterval = fmt.Errorf("invalid interval")
+
+var half = &BCD{digits: []uint8{5}, scale: 1}
+
+// Interval encloses an unknown exact value between a lower and an upper
+// bound. Operations which have to round, like Div or Sqrt, round the
+// lower bound with RoundFloor and the upper bound with RoundCeiling. So
+// the true result of a chain of operations is always enclosed and the
+// width of the interval tells the accumulated rounding error.
+type Interval struct {
+	lo *BCD
+	hi *BCD
+}
+
+// NewInterval creates the interval [lo, hi].
+func NewInterval(lo, hi *BCD) (*Interval, error) {
+	if !lo.IsFinite() || !hi.IsFinite() {
+		return nil, fmt.Errorf("%w: bounds %s and %s are not finite", ErrInvalidInterval, lo, hi)
+	}
+	if lo.GreaterThan(hi) {
+		return nil, fmt.Errorf("%w: lower bound %s is greater than upper bound %s", ErrInvalidInterval, lo, hi)
+	}
+	return &Interval{lo: lo.Copy(), hi: hi.Copy()}, nil
+}
+
+// MustInterval creates the interval [lo, hi] and panics on error.
+func MustInterval(lo, hi *BCD) *Interval {
+	iv, err := NewInterval(lo, hi)
+	if err != nil {
+		panic(fmt.Sprintf("bcd.MustInterval: %v", err))
+	}
+	return iv
+}
+
+// PointInterval creates the interval [x, x] for an exactly known value.
+// Like MustInterval it panics for infinities and NaNs.
+func PointInterval(x *BCD) *Interval {
+	if !x.IsFinite() {
+		panic(fmt.Sprintf("bcd.PointInterval: %v: bound %s is not finite", ErrInvalidInterval, x))
+	}
+	return &Interval{lo: x.Copy(), hi: x.Copy()}
+}
+
+// Lower returns the lower bound.
+func (iv *Interval) Lower() *BCD {
+	return iv.lo.Copy()
+}
+
+// Upper returns the upper bound.
+func (iv *Interval) Upper() *BCD {
+	return iv.hi.Copy()
+}
+
+// Width returns the distance between the bounds.
+func (iv *Interval) Width() *BCD {
+	return iv.hi.Sub(iv.lo)
+}
+
+// Midpoint returns the exact center of the interval.
+func (iv *Interval) Midpoint() *BCD {
+	return iv.lo.Add(iv.hi).Mul(half)
+}
+
+// Radius returns the exact half of the width.
+func (iv *Interval) Radius() *BCD {
+	return iv.Width().Mul(half)
+}
+
+// IsPoint returns true if both bounds are equal.
+func (iv *Interval) IsPoint() bool {
+	return iv.lo.Equal(iv.hi)
+}
+
+// Contains returns true if x lies within the bounds.
+func (iv *Interval) Contains(x *BCD) bool {
+	return iv.lo.LessOrEqual(x) && x.LessOrEqual(iv.hi)
+}
+
+// ContainsZero returns true if zero lies within the bounds.
+func (iv *Interval) ContainsZero() bool {
+	return !iv.lo.IsPositive() && !iv.hi.IsNegative()
+}
+
+// Overlaps returns true if both intervals have at least one value in common.
+func (iv *Interval) Overlaps(other *Interval) bool {
+	return iv.lo.LessOrEqual(other.hi) && other.lo.LessOrEqual(iv.hi)
+}
+
+// Equal returns true if both intervals have the same bounds.
+func (iv *Interval) Equal(other *Interval) bool {
+	return iv.lo.Equal(other.lo) && iv.hi.Equal(other.hi)
+}
+
+// Add returns iv + other. The bounds are added exactly.
+func (iv *Interval) Add(other *Interval) *Interval {
+	return &Interval{lo: iv.lo.Add(other.lo), hi: iv.hi.Add(other.hi)}
+}
+
+// Sub returns iv - other. The bounds are subtracted exactly.
+func (iv *Interval) Sub(other *Interval) *Interval {
+	return &Interval{lo: iv.lo.Sub(other.hi), hi: iv.hi.Sub(other.lo)}
+}
+
+// Mul returns iv * other. The bounds are multiplied exactly.
+func (iv *Interval) Mul(other *Interval) *Interval {
+	return enclose(
+		iv.lo.Mul(other.lo),
+		iv.lo.Mul(other.hi),
+		iv.hi.Mul(other.lo),
+		iv.hi.Mul(other.hi),
+	)
+}
+
+// Div returns iv / other with the bounds rounded outwards to the
+// given scale. A divisor containing zero returns ErrDivisionByZero.
+func (iv *Interval) Div(other *Interval, scale int) (*Interval, error) {
+	if other.ContainsZero() {
+		return nil, fmt.Errorf("%w: divisor %s contains zero", ErrDivisionByZero, other)
+	}
+
+	var los, his []*BCD
+	for _, n := range []*BCD{iv.lo, iv.hi} {
+		for _, d := range []*BCD{other.lo, other.hi} {
+			lo, err := n.Div(d, scale, RoundFloor)
+			if err != nil {
+				return nil, err
+			}
+			hi, err := n.Div(d, scale, RoundCeiling)
+			if err != nil {
+				return nil, err
+			}
+			los = append(los, lo)
+			his = append(his, hi)
+		}
+	}
+
+	return &Interval{lo: enclose(los...).lo, hi: enclose(his...).hi}, nil
+}
+
+// Sqrt returns the square root of the interval with the bounds rounded
+// outwards to the given scale. A negative lower bound returns
+// ErrInvalidOperation.
+func (iv *Interval) Sqrt(scale int) (*Interval, error) {
+	lo, err := iv.lo.Sqrt(scale, RoundFloor)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := iv.hi.Sqrt(scale, RoundCeiling)
+	if err != nil {
+		return nil, err
+	}
+	return &Interval{lo: lo, hi: hi}, nil
+}
+
+// Neg returns the negation of the interval.
+func (iv *Interval) Neg() *Interval {
+	return &Interval{lo: iv.hi.Neg(), hi: iv.lo.Neg()}
+}
+
+// Abs returns the interval of the absolute values.
+func (iv *Interval) Abs() *Interval {
+	switch {
+	case !iv.lo.IsNegative():
+		return &Interval{lo: iv.lo.Copy(), hi: iv.hi.Copy()}
+	case !iv.hi.IsPositive():
+		return iv.Neg()
+	default:
+		return enclose(Zero(), iv.lo.Abs(), iv.hi)
+	}
+}
+
+// Round rounds the bounds outwards to the given number of decimal
+// places, e.g. to limit the growth of digits after multiplications.
+func (iv *Interval) Round(places int) *Interval {
+	return &Interval{lo: iv.lo.Round(places, RoundFloor), hi: iv.hi.Round(places, RoundCeiling)}
+}
+
+// String returns the interval as "[lo, hi]".
+func (iv *Interval) String() string {
+	return "[" + iv.lo.String() + ", " + iv.hi.String() + "]"
+}
+
+// enclose returns the smallest interval containing all values.
+func enclose(values ...*BCD) *Interval {
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		if v.LessThan(lo) {
+			lo = v
+		}
+		if v.GreaterThan(hi) {
+			hi = v
+		}
+	}
+	return &Interval{lo: lo.Copy(), hi: hi.Copy()}
+}
+
+// AmountInterval encloses an unknown exact monetary amount between a
+// lower and an upper bound, e.g. for worst-case exposure reports. Bounds
+// are kept at the decimal places of the currency, rounding the lower
+// bound with RoundFloor and the upper bound with RoundCeiling.
+type AmountInterval struct {
+	interval *Interval
+	info     CurrencyInfo
+}
+
+// NewAmountInterval creates the interval [lo, hi] of two amounts of
+// the same currency.
+func NewAmountInterval(lo, hi *Amount) (*AmountInterval, error) {
+	if lo.info.Code != hi.info.Code {
+		return nil, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, lo.info.Code, hi.info.Code)
+	}
+	iv, err := NewInterval(lo.amount, hi.amount)
+	if err != nil {
+		return nil, err
+	}
+	return &AmountInterval{interval: iv, info: lo.info}, nil
+}
+
+// PointAmountInterval creates the interval [a, a] for an exactly known amount.
+func PointAmountInterval(a *Amount) *AmountInterval {
+	return &AmountInterval{interval: PointInterval(a.amount), info: a.info}
+}
+
+// newAmountInterval creates an amount interval rounding the bounds
+// outwards to the decimal places of the currency.
+func newAmountInterval(iv *Interval, info CurrencyInfo) *AmountInterval {
+	return &AmountInterval{interval: iv.Round(info.DecimalPlaces), info: info}
+}
+
+// Code returns the ISO 4217 currency code.
+func (ai *AmountInterval) Code() string {
+	return ai.info.Code
+}
+
+// Lower returns the lower bound.
+func (ai *AmountInterval) Lower() *Amount {
+	return &Amount{amount: ai.interval.Lower(), info: ai.info}
+}
+
+// Upper returns the upper bound.
+func (ai *AmountInterval) Upper() *Amount {
+	return &Amount{amount: ai.interval.Upper(), info: ai.info}
+}
+
+// Interval returns the bounds as plain BCD interval.
+func (ai *AmountInterval) Interval() *Interval {
+	return &Interval{lo: ai.interval.Lower(), hi: ai.interval.Upper()}
+}
+
+// Width returns the distance between the bounds, the worst-case
+// uncertainty of the amount.
+func (ai *AmountInterval) Width() *Amount {
+	return &Amount{amount: ai.interval.Width(), info: ai.info}
+}
+
+// Midpoint returns the center of the interval rounded to the decimal
+// places of the currency with the given mode.
+func (ai *AmountInterval) Midpoint(mode RoundingMode) *Amount {
+	return &Amount{amount: ai.interval.Midpoint().Round(ai.info.DecimalPlaces, mode), info: ai.info}
+}
+
+// Contains returns true if the amount has the same currency and lies
+// within the bounds.
+func (ai *AmountInterval) Contains(a *Amount) bool {
+	return ai.info.Code == a.info.Code && ai.interval.Contains(a.amount)
+}
+
+// Add returns ai + other, both must have the same currency.
+func (ai *AmountInterval) Add(other *AmountInterval) (*AmountInterval, error) {
+	if ai.info.Code != other.info.Code {
+		return nil, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, ai.info.Code, other.info.Code)
+	}
+	return &AmountInterval{interval: ai.interval.Add(other.interval), info: ai.info}, nil
+}
+
+// Sub returns ai - other, both must have the same currency.
+func (ai *AmountInterval) Sub(other *AmountInterval) (*AmountInterval, error) {
+	if ai.info.Code != other.info.Code {
+		return nil, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, ai.info.Code, other.info.Code)
+	}
+	return &AmountInterval{interval: ai.interval.Sub(other.interval), info: ai.info}, nil
+}
+
+// Mul multiplies the amount interval by an interval of factors, e.g.
+// an uncertain exchange or interest rate.
+func (ai *AmountInterval) Mul(factor *Interval) *AmountInterval {
+	return newAmountInterval(ai.interval.Mul(factor), ai.info)
+}
+
+// Div divides the amount interval by an interval of divisors. A divisor
+// containing zero returns ErrDivisionByZero.
+func (ai *AmountInterval) Div(divisor *Interval) (*AmountInterval, error) {
+	iv, err := ai.interval.Div(divisor, ai.info.DecimalPlaces)
+	if err != nil {
+		return nil, err
+	}
+	return &AmountInterval{interval: iv, info: ai.info}, nil
+}
+
+// String returns the interval as "[lo, hi]" using the amount format.
+func (ai *AmountInterval) String() string {
+	return "[" + ai.Lower().String() + ", " + ai.Upper().String() + "]"
+}
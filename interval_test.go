@@ -0,0 +1,121 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestNewInterval(t *testing.T) {
+	iv, err := NewInterval(Must("1.5"), Must("2.25"))
+	verify.NoError(t, err)
+	verify.Equal(t, iv.String(), "[1.5, 2.25]")
+	verify.Equal(t, iv.Width().String(), "0.75")
+	verify.Equal(t, iv.Midpoint().String(), "1.875")
+	verify.Equal(t, iv.Radius().String(), "0.375")
+	verify.True(t, iv.Contains(Must("2")))
+	verify.False(t, iv.Contains(Must("2.26")))
+	verify.False(t, iv.ContainsZero())
+	verify.False(t, iv.IsPoint())
+	verify.True(t, PointInterval(Must(3)).IsPoint())
+
+	_, err = NewInterval(Must(2), Must(1))
+	verify.IsError(t, err, ErrInvalidInterval)
+	_, err = NewInterval(NaN(), Must(1))
+	verify.IsError(t, err, ErrInvalidInterval)
+	_, err = NewInterval(Must(1), Inf(1))
+	verify.IsError(t, err, ErrInvalidInterval)
+	verify.Panics(t, func() { MustInterval(Inf(-1), Must(1)) })
+	verify.Panics(t, func() { PointInterval(NaN()) })
+	verify.Panics(t, func() { PointInterval(Inf(1)) })
+}
+
+func TestIntervalArithmetic(t *testing.T) {
+	a := MustInterval(Must(1), Must(2))
+	b := MustInterval(Must(-3), Must("0.5"))
+
+	tests := []struct {
+		name   string
+		result *Interval
+		want   string
+	}{
+		{"add", a.Add(b), "[-2, 2.5]"},
+		{"sub", a.Sub(b), "[0.5, 5]"},
+		{"mul", a.Mul(b), "[-6, 1.0]"},
+		{"neg", b.Neg(), "[-0.5, 3]"},
+		{"abs", b.Abs(), "[0, 3]"},
+		{"abs negative", a.Neg().Abs(), "[1, 2]"},
+		{"round", MustInterval(Must("1.234"), Must("1.236")).Round(2), "[1.23, 1.24]"},
+		{"round negative", MustInterval(Must("-1.236"), Must("-1.234")).Round(2), "[-1.24, -1.23]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify.Equal(t, tt.result.String(), tt.want)
+		})
+	}
+}
+
+func TestIntervalDiv(t *testing.T) {
+	one := PointInterval(Must(1))
+	three := PointInterval(Must(3))
+
+	// 1/3 is enclosed by the outwards rounded bounds
+	third, err := one.Div(three, 4)
+	verify.NoError(t, err)
+	verify.Equal(t, third.String(), "[0.3333, 0.3334]")
+
+	// Chained operations keep the true value enclosed
+	back := third.Mul(three)
+	verify.True(t, back.Contains(Must(1)))
+	verify.Equal(t, back.Width().String(), "0.0003")
+
+	neg, err := PointInterval(Must(-2)).Div(three, 2)
+	verify.NoError(t, err)
+	verify.Equal(t, neg.String(), "[-0.67, -0.66]")
+
+	_, err = one.Div(MustInterval(Must(-1), Must(1)), 2)
+	verify.IsError(t, err, ErrDivisionByZero)
+
+	root, err := PointInterval(Must(2)).Sqrt(3)
+	verify.NoError(t, err)
+	verify.Equal(t, root.String(), "[1.414, 1.415]")
+}
+
+func TestAmountInterval(t *testing.T) {
+	lo := MustNewAmount("100.00", "EUR")
+	hi := MustNewAmount("120.00", "EUR")
+	exposure, err := NewAmountInterval(lo, hi)
+	verify.NoError(t, err)
+	verify.Equal(t, exposure.Code(), "EUR")
+
+	// Uncertain rate between 1.0850 and 1.0925
+	rate := MustInterval(Must("1.085"), Must("1.0925"))
+	converted := exposure.Mul(rate)
+	verify.Equal(t, converted.Lower().Amount().String(), "108.50")
+	verify.Equal(t, converted.Upper().Amount().String(), "131.10")
+
+	split, err := exposure.Div(PointInterval(Must(3)))
+	verify.NoError(t, err)
+	verify.Equal(t, split.Interval().String(), "[33.33, 40.00]")
+	verify.Equal(t, split.Width().Amount().String(), "6.67")
+	verify.Equal(t, split.Midpoint(RoundHalfEven).Amount().String(), "36.66")
+	verify.True(t, split.Contains(MustNewAmount("35", "EUR")))
+	verify.False(t, split.Contains(MustNewAmount("35", "USD")))
+
+	sum, err := exposure.Add(split)
+	verify.NoError(t, err)
+	verify.Equal(t, sum.Interval().String(), "[133.33, 160.00]")
+
+	_, err = NewAmountInterval(lo, MustNewAmount("1", "USD"))
+	verify.IsError(t, err, ErrCurrencyMismatch)
+	_, err = exposure.Sub(PointAmountInterval(MustNewAmount("1", "USD")))
+	verify.IsError(t, err, ErrCurrencyMismatch)
+}
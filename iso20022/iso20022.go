@@ -0,0 +1,174 @@
+// Tideland Go BCD - ISO 20022
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Package iso20022 validates and formats amounts to the rules of the
+// ISO 20022 ActiveCurrencyAndAmount type as used by SEPA, pain.001, and
+// pacs.008 messages. The value has at most 18 digits in total and 5
+// fraction digits, no more fraction digits than the minor units of the
+// currency, and is written without sign, exponent, or grouping. The
+// errors name the violated rule.
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"tideland.dev/go/bcd"
+)
+
+// Limits of ActiveCurrencyAndAmount.
+const (
+	MaxTotalDigits    = 18
+	MaxFractionDigits = 5
+)
+
+// CurrencyAttr is the name of the XML attribute with the currency code.
+const CurrencyAttr = "Ccy"
+
+// Rule errors.
+var (
+	ErrTotalDigits    = fmt.Errorf("totalDigits rule violated")
+	ErrFractionDigits = fmt.Errorf("fractionDigits rule violated")
+	ErrMinInclusive   = fmt.Errorf("minInclusive rule violated")
+	ErrPattern        = fmt.Errorf("pattern rule violated")
+	ErrCurrencyAmount = fmt.Errorf("CurrencyAmount rule violated")
+	ErrActiveCurrency = fmt.Errorf("ActiveCurrency rule violated")
+)
+
+// Validate checks the amount against the rules of ActiveCurrencyAndAmount.
+func Validate(a *bcd.Amount) error {
+	if a == nil || a.Amount() == nil {
+		return fmt.Errorf("%w: missing amount", bcd.ErrInvalidAmount)
+	}
+	if a.IsNegative() {
+		return fmt.Errorf("%w: %s is negative", ErrMinInclusive, a)
+	}
+	return checkDigits(a.Format(false, false), a.Code(), a.DecimalPlaces())
+}
+
+// Format validates the amount and returns its text with the minor units
+// of the currency as decimal places, e.g. "1234.50".
+func Format(a *bcd.Amount) (string, error) {
+	if err := Validate(a); err != nil {
+		return "", err
+	}
+	return a.Format(false, false), nil
+}
+
+// Parse parses the text of an amount of the currency. It has to consist
+// of digits with an optional decimal point followed by digits.
+func Parse(s, code string) (*bcd.Amount, error) {
+	info, ok := bcd.GetCurrencyInfo(code)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown currency %q", ErrActiveCurrency, code)
+	}
+	if err := checkPattern(s); err != nil {
+		return nil, err
+	}
+	if err := checkDigits(s, info.Code, info.DecimalPlaces); err != nil {
+		return nil, err
+	}
+	return bcd.NewAmount(s, info.Code)
+}
+
+// ActiveCurrencyAndAmount is an amount valid to the rules of the type.
+// It marshals to XML elements like <InstdAmt Ccy="EUR">12.50</InstdAmt>.
+type ActiveCurrencyAndAmount struct {
+	amount *bcd.Amount
+}
+
+// New validates the amount and returns it as ActiveCurrencyAndAmount.
+func New(a *bcd.Amount) (*ActiveCurrencyAndAmount, error) {
+	if err := Validate(a); err != nil {
+		return nil, err
+	}
+	return &ActiveCurrencyAndAmount{amount: a}, nil
+}
+
+// Amount returns the amount, nil for the zero value.
+func (x *ActiveCurrencyAndAmount) Amount() *bcd.Amount {
+	return x.amount
+}
+
+// String returns the amount text followed by the currency code.
+func (x *ActiveCurrencyAndAmount) String() string {
+	if x.amount == nil {
+		return ""
+	}
+	return x.amount.Format(false, true)
+}
+
+// MarshalXML writes the amount as character data of the element with
+// the currency code as Ccy attribute.
+func (x *ActiveCurrencyAndAmount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := Format(x.amount)
+	if err != nil {
+		return err
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: CurrencyAttr}, Value: x.amount.Code()})
+	return e.EncodeElement(text, start)
+}
+
+// UnmarshalXML reads the amount of an element with Ccy attribute and
+// validates it.
+func (x *ActiveCurrencyAndAmount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var code string
+	for _, attr := range start.Attr {
+		if attr.Name.Local == CurrencyAttr {
+			code = attr.Value
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("%w: missing %s attribute of %s", ErrActiveCurrency, CurrencyAttr, start.Name.Local)
+	}
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	a, err := Parse(strings.TrimSpace(text), code)
+	if err != nil {
+		return err
+	}
+	x.amount = a
+	return nil
+}
+
+// checkPattern checks that the text only consists of digits and an
+// optional decimal point between them.
+func checkPattern(s string) error {
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	if integer == "" || (hasPoint && fraction == "") {
+		return fmt.Errorf("%w: %q is no plain decimal", ErrPattern, s)
+	}
+	for _, part := range []string{integer, fraction} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return fmt.Errorf("%w: %q contains %q, sign, exponent and grouping are not allowed", ErrPattern, s, c)
+			}
+		}
+	}
+	return nil
+}
+
+// checkDigits checks the digit counts of a plain decimal text. Leading
+// zeros of the integer and trailing zeros of the fraction are not
+// counted, like for the facets of XML Schema.
+func checkDigits(s, code string, minorUnits int) error {
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	integer = strings.TrimLeft(integer, "0")
+	fraction = strings.TrimRight(fraction, "0")
+	switch {
+	case len(integer)+len(fraction) > MaxTotalDigits:
+		return fmt.Errorf("%w: %s has %d digits, at most %d allowed", ErrTotalDigits, s, len(integer)+len(fraction), MaxTotalDigits)
+	case len(fraction) > MaxFractionDigits:
+		return fmt.Errorf("%w: %s has %d fraction digits, at most %d allowed", ErrFractionDigits, s, len(fraction), MaxFractionDigits)
+	case len(fraction) > minorUnits:
+		return fmt.Errorf("%w: %s has %d fraction digits, %s has %d minor units", ErrCurrencyAmount, s, len(fraction), code, minorUnits)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Tideland Go BCD - ISO 20022 - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package iso20022
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		value string
+		code  string
+		text  string
+	}{
+		{"1234.5", "EUR", "1234.50"},
+		{"0", "EUR", "0.00"},
+		{"1500", "JPY", "1500"},
+		{"0.5", "USD", "0.50"},
+		{"9999999999999999.99", "EUR", "9999999999999999.99"},
+	}
+	for _, test := range tests {
+		a, err := bcd.NewAmount(test.value, test.code)
+		verify.NoError(t, err)
+		text, err := Format(a)
+		verify.NoError(t, err)
+		verify.Equal(t, text, test.text)
+	}
+
+	negative, err := bcd.NewAmount("-1", "EUR")
+	verify.NoError(t, err)
+	_, err = Format(negative)
+	verify.IsError(t, err, ErrMinInclusive)
+	long, err := bcd.NewAmount("99999999999999999.99", "EUR")
+	verify.NoError(t, err)
+	_, err = Format(long)
+	verify.IsError(t, err, ErrTotalDigits)
+	verify.True(t, strings.Contains(err.Error(), "totalDigits"))
+	_, err = Format(nil)
+	verify.IsError(t, err, bcd.ErrInvalidAmount)
+}
+
+func TestParse(t *testing.T) {
+	a, err := Parse("1234.5", "eur")
+	verify.NoError(t, err)
+	verify.Equal(t, a.Code(), "EUR")
+	verify.Equal(t, a.Format(false, false), "1234.50")
+
+	// Trailing fraction zeros are not counted.
+	a, err = Parse("1500.000000", "JPY")
+	verify.NoError(t, err)
+	verify.Equal(t, a.Format(false, false), "1500")
+
+	tests := []struct {
+		text string
+		code string
+		err  error
+	}{
+		{"-1.00", "EUR", ErrPattern},
+		{"+1.00", "EUR", ErrPattern},
+		{"1E3", "EUR", ErrPattern},
+		{"1,000.00", "EUR", ErrPattern},
+		{"1 000.00", "EUR", ErrPattern},
+		{".5", "EUR", ErrPattern},
+		{"5.", "EUR", ErrPattern},
+		{"", "EUR", ErrPattern},
+		{"1234567890123456789", "EUR", ErrTotalDigits},
+		{"1.000001", "EUR", ErrFractionDigits},
+		{"1.001", "EUR", ErrCurrencyAmount},
+		{"1.5", "JPY", ErrCurrencyAmount},
+		{"1.00", "ABC", ErrActiveCurrency},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.text, test.code)
+		verify.IsError(t, err, test.err)
+	}
+}
+
+func TestXML(t *testing.T) {
+	type transaction struct {
+		XMLName  xml.Name                 `xml:"CdtTrfTxInf"`
+		InstdAmt *ActiveCurrencyAndAmount `xml:"InstdAmt"`
+	}
+	a, err := bcd.NewAmount("12.5", "EUR")
+	verify.NoError(t, err)
+	amt, err := New(a)
+	verify.NoError(t, err)
+	data, err := xml.Marshal(transaction{InstdAmt: amt})
+	verify.NoError(t, err)
+	verify.Equal(t, string(data), `<CdtTrfTxInf><InstdAmt Ccy="EUR">12.50</InstdAmt></CdtTrfTxInf>`)
+
+	var tx transaction
+	err = xml.Unmarshal([]byte(`<CdtTrfTxInf><InstdAmt Ccy="CHF"> 1000.05 </InstdAmt></CdtTrfTxInf>`), &tx)
+	verify.NoError(t, err)
+	verify.Equal(t, tx.InstdAmt.String(), "1000.05 CHF")
+	verify.Equal(t, tx.InstdAmt.Amount().Code(), "CHF")
+
+	tests := []struct {
+		doc string
+		err error
+	}{
+		{`<CdtTrfTxInf><InstdAmt>1.00</InstdAmt></CdtTrfTxInf>`, ErrActiveCurrency},
+		{`<CdtTrfTxInf><InstdAmt Ccy="EUR">-1.00</InstdAmt></CdtTrfTxInf>`, ErrPattern},
+		{`<CdtTrfTxInf><InstdAmt Ccy="EUR">1.005</InstdAmt></CdtTrfTxInf>`, ErrCurrencyAmount},
+	}
+	for _, test := range tests {
+		err := xml.Unmarshal([]byte(test.doc), &tx)
+		verify.IsError(t, err, test.err)
+	}
+
+	a, err = bcd.NewAmount("-12.5", "EUR")
+	verify.NoError(t, err)
+	_, err = New(a)
+	verify.IsError(t, err, ErrMinInclusive)
+}
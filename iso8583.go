@@ -0,0 +1,161 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layout of ISO 8583 amounts and the additional amounts of field 54.
+const (
+	iso8583AmountDigits     = 12
+	iso8583AdditionalLength = 20
+	iso8583MaxAdditional    = 6
+)
+
+// NewAmountFromISO8583 creates an Amount from an ISO 8583 amount field
+// of type n12 in minor units and a numeric currency code like in field
+// 49, e.g. "000000012345" and "978" for 123.45 EUR. A leading C for
+// credit or D for debit like in the x+n fields is accepted, debits are
+// negative. Amounts have no negative zero, so the mark of a zero debit
+// is only kept by AdditionalAmount.
+func NewAmountFromISO8583(n12, numericCode string) (*Amount, error) {
+	info, ok := GetCurrencyInfoByNumericCode(numericCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: numeric code %q", ErrUnknownCurrency, numericCode)
+	}
+	digits := n12
+	negative := false
+	if len(digits) == iso8583AmountDigits+1 {
+		switch digits[0] {
+		case 'C':
+		case 'D':
+			negative = true
+		default:
+			return nil, fmt.Errorf("%w: invalid sign %q of ISO 8583 amount", ErrInvalidAmount, digits[0])
+		}
+		digits = digits[1:]
+	}
+	if len(digits) != iso8583AmountDigits {
+		return nil, fmt.Errorf("%w: ISO 8583 amount %q needs %d digits", ErrInvalidAmount, n12, iso8583AmountDigits)
+	}
+	value, err := ParseFixedWidth(digits, info.DecimalPlaces, SignNone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	if negative {
+		value = value.Neg()
+	}
+	return NewAmount(value, info.Code)
+}
+
+// ToISO8583 returns the amount as ISO 8583 amount field of type n12 in
+// minor units and the numeric currency code. With signed the amount is
+// prefixed by C for credit or D for debit, otherwise negative amounts
+// return ErrInvalidAmount. Amounts with more than 12 digits return
+// ErrOverflow.
+func (c *Amount) ToISO8583(signed bool) (string, string, error) {
+	if c == nil || c.amount == nil {
+		return "", "", ErrInvalidAmount
+	}
+	if c.amount.IsNegative() && !signed {
+		return "", "", fmt.Errorf("%w: negative amount %s needs a signed field", ErrInvalidAmount, c)
+	}
+	digits, err := c.amount.Abs().FormatFixedWidth(iso8583AmountDigits, c.info.DecimalPlaces, SignNone)
+	if err != nil {
+		return "", "", err
+	}
+	if signed {
+		return creditDebitMark(c.amount.IsNegative()) + digits, c.info.NumericCode, nil
+	}
+	return digits, c.info.NumericCode, nil
+}
+
+// AdditionalAmount is one amount of the ISO 8583 field 54, e.g. the
+// available balance of an account.
+type AdditionalAmount struct {
+	AccountType string // Two digits, e.g. "10" for savings
+	AmountType  string // Two digits, e.g. "02" for the available balance
+	Amount      *Amount
+	Debit       bool // Debit mark, also of zero amounts
+}
+
+// ParseISO8583AdditionalAmounts parses the content of the ISO 8583 field
+// 54 with up to six amounts of 20 characters each. Every amount consists
+// of account type, amount type, numeric currency code, C or D as sign,
+// and the amount as n12 in minor units.
+func ParseISO8583AdditionalAmounts(field string) ([]AdditionalAmount, error) {
+	if len(field)%iso8583AdditionalLength != 0 || len(field) > iso8583MaxAdditional*iso8583AdditionalLength {
+		return nil, fmt.Errorf("%w: ISO 8583 additional amounts of length %d", ErrInvalidFormat, len(field))
+	}
+	amounts := make([]AdditionalAmount, 0, len(field)/iso8583AdditionalLength)
+	for i := 0; i < len(field); i += iso8583AdditionalLength {
+		entry := field[i : i+iso8583AdditionalLength]
+		if !isDigitString(entry[:7]) {
+			return nil, fmt.Errorf("%w: ISO 8583 additional amount %q", ErrInvalidFormat, entry)
+		}
+		amount, err := NewAmountFromISO8583(entry[7:], entry[4:7])
+		if err != nil {
+			return nil, fmt.Errorf("additional amount %d: %w", i/iso8583AdditionalLength+1, err)
+		}
+		amounts = append(amounts, AdditionalAmount{
+			AccountType: entry[0:2],
+			AmountType:  entry[2:4],
+			Amount:      amount,
+			Debit:       entry[7] == 'D',
+		})
+	}
+	return amounts, nil
+}
+
+// FormatISO8583AdditionalAmounts returns the content of the ISO 8583
+// field 54 for up to six amounts. Debit and negative amounts are marked
+// with D.
+func FormatISO8583AdditionalAmounts(amounts []AdditionalAmount) (string, error) {
+	if len(amounts) > iso8583MaxAdditional {
+		return "", fmt.Errorf("%w: %d additional amounts exceed %d", ErrOverflow, len(amounts), iso8583MaxAdditional)
+	}
+	var sb strings.Builder
+	sb.Grow(len(amounts) * iso8583AdditionalLength)
+	for i, a := range amounts {
+		if len(a.AccountType) != 2 || len(a.AmountType) != 2 || !isDigitString(a.AccountType+a.AmountType) {
+			return "", fmt.Errorf("%w: account type %q and amount type %q need two digits",
+				ErrInvalidFormat, a.AccountType, a.AmountType)
+		}
+		n12, numericCode, err := a.Amount.ToISO8583(true)
+		if err != nil {
+			return "", fmt.Errorf("additional amount %d: %w", i+1, err)
+		}
+		sb.WriteString(a.AccountType)
+		sb.WriteString(a.AmountType)
+		sb.WriteString(numericCode)
+		sb.WriteString(creditDebitMark(a.Debit || a.Amount.IsNegative()))
+		sb.WriteString(n12[1:])
+	}
+	return sb.String(), nil
+}
+
+// creditDebitMark returns D for negative values and C otherwise.
+func creditDebitMark(negative bool) string {
+	if negative {
+		return "D"
+	}
+	return "C"
+}
+
+// isDigitString returns true if the string only contains the digits
+// 0 to 9.
+func isDigitString(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestCurrencyInfoByNumericCode(t *testing.T) {
+	info, ok := GetCurrencyInfoByNumericCode("978")
+	verify.True(t, ok)
+	verify.Equal(t, info.Code, "EUR")
+	info, ok = GetCurrencyInfoByNumericCode("392")
+	verify.True(t, ok)
+	verify.Equal(t, info.Code, "JPY")
+	_, ok = GetCurrencyInfoByNumericCode("000")
+	verify.True(t, !ok)
+
+	a, err := NewAmount("1", "USD")
+	verify.NoError(t, err)
+	verify.Equal(t, a.NumericCode(), "840")
+}
+
+func TestISO8583Amount(t *testing.T) {
+	tests := []struct {
+		n12         string
+		numericCode string
+		value       string
+		signed      bool
+	}{
+		{"000000012345", "978", "123.45 EUR", false},
+		{"000000000000", "840", "0.00 USD", false},
+		{"000000012345", "392", "12345 JPY", false},
+		{"999999999999", "840", "9999999999.99 USD", false},
+		{"C000000012345", "978", "123.45 EUR", true},
+		{"D000000012345", "978", "-123.45 EUR", true},
+	}
+	for _, test := range tests {
+		a, err := NewAmountFromISO8583(test.n12, test.numericCode)
+		verify.NoError(t, err)
+		verify.Equal(t, a.Format(false, true), test.value)
+
+		n12, numericCode, err := a.ToISO8583(test.signed)
+		verify.NoError(t, err)
+		verify.Equal(t, n12, test.n12)
+		verify.Equal(t, numericCode, test.numericCode)
+	}
+
+	_, err := NewAmountFromISO8583("000000012345", "999")
+	verify.IsError(t, err, ErrUnknownCurrency)
+	_, err = NewAmountFromISO8583("12345", "978")
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = NewAmountFromISO8583("X000000012345", "978")
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = NewAmountFromISO8583("00000001234A", "978")
+	verify.IsError(t, err, ErrInvalidAmount)
+	verify.IsError(t, err, ErrInvalidFormat)
+
+	a, err := NewAmount("-1.50", "EUR")
+	verify.NoError(t, err)
+	_, _, err = a.ToISO8583(false)
+	verify.IsError(t, err, ErrInvalidAmount)
+	a, err = NewAmount("10000000000", "EUR")
+	verify.NoError(t, err)
+	_, _, err = a.ToISO8583(false)
+	verify.IsError(t, err, ErrOverflow)
+}
+
+func TestISO8583AdditionalAmounts(t *testing.T) {
+	field := "1002840C000000012345" + "1001840D000000000750" + "1001978D000000000000"
+	amounts, err := ParseISO8583AdditionalAmounts(field)
+	verify.NoError(t, err)
+	verify.Equal(t, len(amounts), 3)
+	verify.Equal(t, amounts[0].AccountType, "10")
+	verify.Equal(t, amounts[0].AmountType, "02")
+	verify.Equal(t, amounts[0].Amount.Format(false, true), "123.45 USD")
+	verify.Equal(t, amounts[1].AmountType, "01")
+	verify.Equal(t, amounts[1].Amount.Format(false, true), "-7.50 USD")
+	verify.True(t, amounts[1].Debit)
+	verify.Equal(t, amounts[2].Amount.Format(false, true), "0.00 EUR")
+	verify.True(t, amounts[2].Debit)
+
+	back, err := FormatISO8583AdditionalAmounts(amounts)
+	verify.NoError(t, err)
+	verify.Equal(t, back, field)
+
+	empty, err := ParseISO8583AdditionalAmounts("")
+	verify.NoError(t, err)
+	verify.Equal(t, len(empty), 0)
+
+	invalid := []struct {
+		field string
+		err   error
+	}{
+		{"1002840C00000001234", ErrInvalidFormat},
+		{"1A02840C000000012345", ErrInvalidFormat},
+		{"1002999C000000012345", ErrUnknownCurrency},
+		{"1002840X000000012345", ErrInvalidAmount},
+	}
+	for _, test := range invalid {
+		_, err := ParseISO8583AdditionalAmounts(test.field)
+		verify.IsError(t, err, test.err)
+	}
+
+	_, err = FormatISO8583AdditionalAmounts(make([]AdditionalAmount, 7))
+	verify.IsError(t, err, ErrOverflow)
+	_, err = FormatISO8583AdditionalAmounts([]AdditionalAmount{{AccountType: "1", AmountType: "02", Amount: amounts[0].Amount}})
+	verify.IsError(t, err, ErrInvalidFormat)
+}
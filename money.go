@@ -0,0 +1,153 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Limits of the google.type shapes.
+const (
+	nanosPerUnit = 1_000_000_000
+	nanosScale   = 9
+
+	// maxDecimalExponent limits the exponent of decimal strings to the
+	// one of IEEE 754 decimal128, so a few bytes cannot request huge
+	// numbers of digits.
+	maxDecimalExponent = 6144
+)
+
+// MoneyParts has the layout of google.type.Money without depending on
+// its protobuf package. Units are the whole units of the currency, Nanos
+// the nano units of the amount in the range -999,999,999 to +999,999,999.
+// Both have the same sign, e.g. -1.75 is Units -1 and Nanos -750,000,000.
+type MoneyParts struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+// ToMoneyParts converts the amount into the layout of google.type.Money.
+// It returns ErrOverflow if the units do not fit into an int64.
+func (c *Amount) ToMoneyParts() (MoneyParts, error) {
+	b := c.amount
+	if b.scale > nanosScale {
+		return MoneyParts{}, fmt.Errorf("%w: %s has more than %d decimal places", ErrPrecisionLoss, b, nanosScale)
+	}
+
+	// Negative units reach down to math.MinInt64
+	limit := uint64(math.MaxInt64)
+	if b.IsNegative() {
+		limit++
+	}
+	var units uint64
+	for i := len(b.digits) - 1; i >= b.scale; i-- {
+		d := uint64(b.digits[i])
+		if units > (limit-d)/10 {
+			return MoneyParts{}, fmt.Errorf("%w: units of %s exceed int64", ErrOverflow, b)
+		}
+		units = units*10 + d
+	}
+	var nanos int32
+	for i := b.scale - 1; i >= 0; i-- {
+		nanos = nanos*10 + int32(digitAt(b, i))
+	}
+	for range nanosScale - b.scale {
+		nanos *= 10
+	}
+
+	p := MoneyParts{
+		CurrencyCode: c.info.Code,
+		Units:        int64(units),
+		Nanos:        nanos,
+	}
+	if b.IsNegative() {
+		// Negating as uint64 also covers math.MinInt64
+		p.Units, p.Nanos = int64(-units), -nanos
+	}
+	return p, nil
+}
+
+// FromMoneyParts creates an amount from the layout of google.type.Money.
+// Nanos out of range or with a sign different to the units return
+// ErrInvalidAmount, nanos finer than the decimal places of the currency
+// ErrPrecisionLoss.
+func FromMoneyParts(p MoneyParts) (*Amount, error) {
+	switch {
+	case p.Nanos <= -nanosPerUnit || p.Nanos >= nanosPerUnit:
+		return nil, fmt.Errorf("%w: nanos %d out of range", ErrInvalidAmount, p.Nanos)
+	case p.Units > 0 && p.Nanos < 0, p.Units < 0 && p.Nanos > 0:
+		return nil, fmt.Errorf("%w: units %d and nanos %d have different signs", ErrInvalidAmount, p.Units, p.Nanos)
+	}
+
+	nanos := fromInt64(int64(p.Nanos))
+	nanos.scale = nanosScale
+	value := fromInt64(p.Units).Add(nanos)
+	a, err := NewAmount(value, p.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if !a.amount.Equal(value) {
+		return nil, fmt.Errorf("%w: %d nanos exceed the %d decimal places of %s",
+			ErrPrecisionLoss, p.Nanos, a.info.DecimalPlaces, a.info.Code)
+	}
+	return a, nil
+}
+
+// ToGoogleDecimal returns the value in the string form of
+// google.type.Decimal. NaNs and infinities have no such form and
+// return ErrInvalidOperation.
+func (b *BCD) ToGoogleDecimal() (string, error) {
+	if !b.IsFinite() {
+		return "", fmt.Errorf("%w: %s has no decimal string form", ErrInvalidOperation, b)
+	}
+	return b.String(), nil
+}
+
+// FromGoogleDecimal parses the string form of google.type.Decimal, e.g.
+// "-2.5", ".5" or "1.25E+3". Different to New it strictly follows the
+// grammar, so surrounding spaces and special values are rejected, and
+// exponents are applied exactly. Exponents beyond ±6144 return
+// ErrOverflow.
+func FromGoogleDecimal(s string) (*BCD, error) {
+	significand, exponent, hasExponent := strings.Cut(strings.ReplaceAll(s, "E", "e"), "e")
+	b, ok := parseBytes([]byte(significand))
+	if !ok || strings.TrimSpace(significand) != significand {
+		return nil, fmt.Errorf("%w: %q is no decimal string", ErrInvalidFormat, s)
+	}
+	if !hasExponent {
+		return b, nil
+	}
+
+	exp, err := strconv.Atoi(exponent)
+	switch {
+	case errors.Is(err, strconv.ErrRange), exp < -maxDecimalExponent, exp > maxDecimalExponent:
+		return nil, fmt.Errorf("%w: exponent of %q", ErrOverflow, s)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %q has an invalid exponent", ErrInvalidFormat, s)
+	}
+	return applyExponent(b, exp), nil
+}
+
+// applyExponent multiplies the finite value by 10^exp in place and
+// returns it. Positive exponents beyond the scale add zero digits.
+func applyExponent(b *BCD, exp int) *BCD {
+	if b.IsZero() {
+		return b
+	}
+	b.scale -= exp
+	if b.scale < 0 {
+		b.digits = append(make([]uint8, -b.scale), b.digits...)
+		b.scale = 0
+	}
+	return b
+}
@@ -0,0 +1,141 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestMoneyParts(t *testing.T) {
+	tests := []struct {
+		amount string
+		code   string
+		parts  MoneyParts
+	}{
+		{"1.75", "USD", MoneyParts{"USD", 1, 750_000_000}},
+		{"-1.75", "USD", MoneyParts{"USD", -1, -750_000_000}},
+		{"-0.01", "EUR", MoneyParts{"EUR", 0, -10_000_000}},
+		{"0", "EUR", MoneyParts{"EUR", 0, 0}},
+		{"1500", "JPY", MoneyParts{"JPY", 1500, 0}},
+		{"0.00000001", "BTC", MoneyParts{"BTC", 0, 10}},
+		{"9223372036854775807.99", "EUR", MoneyParts{"EUR", math.MaxInt64, 990_000_000}},
+		{"-9223372036854775807", "EUR", MoneyParts{"EUR", -math.MaxInt64, 0}},
+		{"-9223372036854775808.99", "EUR", MoneyParts{"EUR", math.MinInt64, -990_000_000}},
+	}
+	for _, test := range tests {
+		a := MustNewAmount(test.amount, test.code)
+		p, err := a.ToMoneyParts()
+		verify.NoError(t, err)
+		verify.Equal(t, p, test.parts)
+
+		back, err := FromMoneyParts(p)
+		verify.NoError(t, err)
+		verify.True(t, back.Equal(a))
+	}
+
+	_, err := MustNewAmount("9223372036854775808", "EUR").ToMoneyParts()
+	verify.IsError(t, err, ErrOverflow)
+	_, err = MustNewAmount("-9223372036854775809", "EUR").ToMoneyParts()
+	verify.IsError(t, err, ErrOverflow)
+	_, err = MustNewAmount("-99999999999999999999.5", "EUR").ToMoneyParts()
+	verify.IsError(t, err, ErrOverflow)
+
+	// Both int64 limits of the units convert back and forth
+	for _, units := range []int64{math.MaxInt64, math.MinInt64} {
+		p := MoneyParts{"USD", units, 0}
+		a, err := FromMoneyParts(p)
+		verify.NoError(t, err)
+		verify.True(t, a.Amount().Equal(Must(units)))
+		back, err := a.ToMoneyParts()
+		verify.NoError(t, err)
+		verify.Equal(t, back, p)
+	}
+}
+
+func TestFromMoneyPartsValidation(t *testing.T) {
+	tests := []struct {
+		parts MoneyParts
+		err   error
+	}{
+		{MoneyParts{"EUR", 1, -500_000_000}, ErrInvalidAmount},
+		{MoneyParts{"EUR", -1, 500_000_000}, ErrInvalidAmount},
+		{MoneyParts{"EUR", 0, 1_000_000_000}, ErrInvalidAmount},
+		{MoneyParts{"EUR", 0, -1_000_000_000}, ErrInvalidAmount},
+		{MoneyParts{"EUR", 1, 5_000_000}, ErrPrecisionLoss},
+		{MoneyParts{"JPY", 1, 1}, ErrPrecisionLoss},
+		{MoneyParts{"XYZ", 1, 0}, ErrUnknownCurrency},
+	}
+	for _, test := range tests {
+		_, err := FromMoneyParts(test.parts)
+		verify.IsError(t, err, test.err)
+	}
+
+	// Zero units allow nanos of both signs
+	a, err := FromMoneyParts(MoneyParts{"eur", 0, -500_000_000})
+	verify.NoError(t, err)
+	verify.Equal(t, a.Format(false, true), "-0.50 EUR")
+}
+
+func TestGoogleDecimal(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+		err      error
+	}{
+		{"2.5", "2.5", nil},
+		{"+2.5", "2.5", nil},
+		{"-2.50", "-2.5", nil},
+		{".5", "0.5", nil},
+		{"5.", "5", nil},
+		{"2.5e8", "250000000", nil},
+		{"2.5E+8", "250000000", nil},
+		{"2.5E0", "2.5", nil},
+		{"-125E-5", "-0.00125", nil},
+		{"0E10", "0", nil},
+		{"12345678901234567890.123456789E-3", "12345678901234567.890123456789", nil},
+		{"1E6144", "", nil},
+		{"1E6145", "", ErrOverflow},
+		{"1E-99999999999999999999", "", ErrOverflow},
+		{"", "", ErrInvalidFormat},
+		{" 1", "", ErrInvalidFormat},
+		{"1 ", "", ErrInvalidFormat},
+		{".", "", ErrInvalidFormat},
+		{"1e", "", ErrInvalidFormat},
+		{"1e+", "", ErrInvalidFormat},
+		{"1e 5", "", ErrInvalidFormat},
+		{"1e2e3", "", ErrInvalidFormat},
+		{"NaN", "", ErrInvalidFormat},
+		{"Infinity", "", ErrInvalidFormat},
+		{"0x10", "", ErrInvalidFormat},
+		{"1_000", "", ErrInvalidFormat},
+	}
+	for _, test := range tests {
+		b, err := FromGoogleDecimal(test.in)
+		if test.err != nil {
+			verify.IsError(t, err, test.err)
+			continue
+		}
+		verify.NoError(t, err)
+		if test.expected != "" {
+			verify.Equal(t, b.String(), test.expected)
+		}
+		s, err := b.ToGoogleDecimal()
+		verify.NoError(t, err)
+		back, err := FromGoogleDecimal(s)
+		verify.NoError(t, err)
+		verify.True(t, back.Equal(b))
+	}
+
+	_, err := NaN().ToGoogleDecimal()
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Inf(1).ToGoogleDecimal()
+	verify.IsError(t, err, ErrInvalidOperation)
+}
@@ -0,0 +1,169 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MessagePack extension types of BCD and Amount. The payload is the
+// binary form of AppendBinary. Applications with clashing extensions
+// use a MsgpackCodec with other types.
+const (
+	MsgpackExtBCD    int8 = 1
+	MsgpackExtAmount int8 = 2
+)
+
+// MsgpackCodec encodes and decodes values as MessagePack extensions of
+// the given types. It has no state beside the types, so one codec can be
+// used concurrently.
+type MsgpackCodec struct {
+	BCDExt    int8
+	AmountExt int8
+}
+
+// defaultMsgpackCodec is used by the MarshalMsgpack and UnmarshalMsgpack
+// methods.
+var defaultMsgpackCodec = MsgpackCodec{
+	BCDExt:    MsgpackExtBCD,
+	AmountExt: MsgpackExtAmount,
+}
+
+// MarshalBCD returns the value as MessagePack extension of type BCDExt.
+func (mc MsgpackCodec) MarshalBCD(b *BCD) ([]byte, error) {
+	payload, err := b.AppendBinary(nil)
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgpackExt(nil, mc.BCDExt, payload), nil
+}
+
+// UnmarshalBCD reads a MessagePack extension of type BCDExt.
+func (mc MsgpackCodec) UnmarshalBCD(data []byte) (*BCD, error) {
+	payload, err := readMsgpackExt(data, mc.BCDExt)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBinary(payload)
+}
+
+// MarshalAmount returns the amount as MessagePack extension of type
+// AmountExt.
+func (mc MsgpackCodec) MarshalAmount(c *Amount) ([]byte, error) {
+	payload, err := c.AppendBinary(nil)
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgpackExt(nil, mc.AmountExt, payload), nil
+}
+
+// UnmarshalAmount reads a MessagePack extension of type AmountExt.
+func (mc MsgpackCodec) UnmarshalAmount(data []byte) (*Amount, error) {
+	payload, err := readMsgpackExt(data, mc.AmountExt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	var c Amount
+	if err := c.UnmarshalBinary(payload); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// MarshalMsgpack returns the value as MessagePack extension of type
+// MsgpackExtBCD.
+func (b *BCD) MarshalMsgpack() ([]byte, error) {
+	return defaultMsgpackCodec.MarshalBCD(b)
+}
+
+// UnmarshalMsgpack reads a MessagePack extension of type MsgpackExtBCD.
+func (b *BCD) UnmarshalMsgpack(data []byte) error {
+	parsed, err := defaultMsgpackCodec.UnmarshalBCD(data)
+	if err != nil {
+		return err
+	}
+	*b = *parsed
+	return nil
+}
+
+// MarshalMsgpack returns the amount as MessagePack extension of type
+// MsgpackExtAmount.
+func (c *Amount) MarshalMsgpack() ([]byte, error) {
+	return defaultMsgpackCodec.MarshalAmount(c)
+}
+
+// UnmarshalMsgpack reads a MessagePack extension of type MsgpackExtAmount.
+func (c *Amount) UnmarshalMsgpack(data []byte) error {
+	parsed, err := defaultMsgpackCodec.UnmarshalAmount(data)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// appendMsgpackExt appends the payload as extension, using the fixext
+// formats for their lengths and ext 8, 16, or 32 otherwise.
+func appendMsgpackExt(dst []byte, typ int8, payload []byte) []byte {
+	n := len(payload)
+	switch {
+	case n == 1:
+		dst = append(dst, 0xd4)
+	case n == 2:
+		dst = append(dst, 0xd5)
+	case n == 4:
+		dst = append(dst, 0xd6)
+	case n == 8:
+		dst = append(dst, 0xd7)
+	case n == 16:
+		dst = append(dst, 0xd8)
+	case n <= 0xff:
+		dst = append(dst, 0xc7, byte(n))
+	case n <= 0xffff:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xc8), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xc9), uint32(n))
+	}
+	dst = append(dst, byte(typ))
+	return append(dst, payload...)
+}
+
+// readMsgpackExt returns the payload of the extension with the expected
+// type. The extension has to fill the data completely.
+func readMsgpackExt(data []byte, expected int8) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("%w: MessagePack extension too short", ErrInvalidFormat)
+	}
+	var n, head int
+	switch data[0] {
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		n, head = 1<<(data[0]-0xd4), 1
+	case 0xc7:
+		n, head = int(data[1]), 2
+	case 0xc8:
+		if len(data) < 3 {
+			return nil, fmt.Errorf("%w: MessagePack extension too short", ErrInvalidFormat)
+		}
+		n, head = int(binary.BigEndian.Uint16(data[1:])), 3
+	case 0xc9:
+		if len(data) < 5 {
+			return nil, fmt.Errorf("%w: MessagePack extension too short", ErrInvalidFormat)
+		}
+		n, head = int(binary.BigEndian.Uint32(data[1:])), 5
+	default:
+		return nil, fmt.Errorf("%w: no MessagePack extension 0x%02x", ErrInvalidFormat, data[0])
+	}
+	if len(data) != head+1+n {
+		return nil, fmt.Errorf("%w: MessagePack extension length %d", ErrInvalidFormat, n)
+	}
+	if typ := int8(data[head]); typ != expected {
+		return nil, fmt.Errorf("%w: MessagePack extension type %d, expected %d", ErrInvalidFormat, typ, expected)
+	}
+	return data[head+1:], nil
+}
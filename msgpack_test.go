@@ -0,0 +1,90 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestMsgpack(t *testing.T) {
+	tests := []struct {
+		value   *BCD
+		msgpack string
+	}{
+		{Inf(-1), "d40181"},
+		{Must("12.5"), "d6010001125c"},
+		{Must("1"), "c7030100001c"},
+		{Must("-123456789012345678901234567"), "d8010000123456789012345678901234567d"},
+	}
+	for _, test := range tests {
+		data, err := test.value.MarshalMsgpack()
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.msgpack, test.value.String())
+
+		var back BCD
+		verify.NoError(t, back.UnmarshalMsgpack(data))
+		verify.True(t, back.Identical(test.value))
+	}
+
+	// Long payloads use ext 16
+	long := Must("1" + string(bytes.Repeat([]byte("9"), 600)))
+	data, err := long.MarshalMsgpack()
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data[:4]), "c8012f01")
+	var back BCD
+	verify.NoError(t, back.UnmarshalMsgpack(data))
+	verify.True(t, back.Equal(long))
+
+	for _, s := range []string{"", "d4", "d50181", "d40281", "c0", "c7020181", "c8000101", "c9000000"} {
+		verify.IsError(t, back.UnmarshalMsgpack(fromHex(t, s)), ErrInvalidFormat)
+	}
+}
+
+func TestMsgpackAmount(t *testing.T) {
+	a := MustNewAmount("12.34", "EUR")
+	data, err := a.MarshalMsgpack()
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "d702455552000201234c")
+
+	var back Amount
+	verify.NoError(t, back.UnmarshalMsgpack(data))
+	verify.True(t, back.Equal(a))
+
+	// The extension type must match
+	b, err := Must("1").MarshalMsgpack()
+	verify.NoError(t, err)
+	verify.IsError(t, back.UnmarshalMsgpack(b), ErrInvalidAmount)
+}
+
+func TestMsgpackCodec(t *testing.T) {
+	codec := MsgpackCodec{BCDExt: 42, AmountExt: -7}
+
+	data, err := codec.MarshalBCD(Must("12.5"))
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "d62a0001125c")
+	b, err := codec.UnmarshalBCD(data)
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "12.5")
+	var back BCD
+	verify.IsError(t, back.UnmarshalMsgpack(data), ErrInvalidFormat)
+
+	a := MustNewAmount("12.34", "EUR")
+	data, err = codec.MarshalAmount(a)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "d7f9455552000201234c")
+	got, err := codec.UnmarshalAmount(data)
+	verify.NoError(t, err)
+	verify.True(t, got.Equal(a))
+	_, err = defaultMsgpackCodec.UnmarshalAmount(data)
+	verify.IsError(t, err, ErrInvalidAmount)
+	verify.IsError(t, err, ErrInvalidFormat)
+}
@@ -0,0 +1,338 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+// Var is a mutable BCD for hot loops. Like the types of math/big its
+// methods set the receiver to the result, e.g. z.Add(x, y) sets z to
+// x + y, and return it for chaining. The digit buffers of z are reused,
+// so once they are large enough no more memory is allocated. Operands
+// may be the embedded BCD of the receiver itself:
+//
+//	z := bcd.NewVar(bcd.Zero())
+//	for _, row := range rows {
+//		z.Add(&z.BCD, row)
+//	}
+//	total := z.Copy()
+//
+// All read-only methods of BCD are available through the embedding.
+// The embedded BCD changes with every operation, so it must not be kept
+// beyond the next one. Use Copy to get an immutable BCD. The zero value
+// of Var is 0. A Var is not safe for concurrent use.
+type Var struct {
+	BCD
+	scratch []uint8
+}
+
+// NewVar creates a Var set to x.
+func NewVar(x *BCD) *Var {
+	z := &Var{}
+	return z.Set(x)
+}
+
+// Set sets z to x and returns z.
+func (z *Var) Set(x *BCD) *Var {
+	if x == &z.BCD {
+		return z
+	}
+	z.digits = append(z.digits[:0], x.digits...)
+	z.scale = x.scale
+	z.negative = x.negative
+	z.form = x.form
+	return z
+}
+
+// SetInt64 sets z to n and returns z.
+func (z *Var) SetInt64(n int64) *Var {
+	magnitude := uint64(n)
+	if n < 0 {
+		magnitude = uint64(-(n + 1)) + 1
+	}
+	z.digits = z.digits[:0]
+	for {
+		z.digits = append(z.digits, uint8(magnitude%10))
+		magnitude /= 10
+		if magnitude == 0 {
+			break
+		}
+	}
+	z.scale = 0
+	z.negative = n < 0
+	z.form = finite
+	return z
+}
+
+// Add sets z to x + y and returns z.
+func (z *Var) Add(x, y *BCD) *Var {
+	if x.form != finite || y.form != finite {
+		return z.Set(x.Add(y))
+	}
+	return z.add(x, y, y.negative)
+}
+
+// Sub sets z to x - y and returns z.
+func (z *Var) Sub(x, y *BCD) *Var {
+	if x.form != finite || y.form != finite {
+		return z.Set(x.Sub(y))
+	}
+	return z.add(x, y, !y.negative)
+}
+
+// Mul sets z to x * y and returns z.
+func (z *Var) Mul(x, y *BCD) *Var {
+	if x.form != finite || y.form != finite || x.IsZero() || y.IsZero() {
+		return z.Set(x.Mul(y))
+	}
+
+	n := len(x.digits) + len(y.digits)
+	buf := grow(z.scratch, n)
+	clear(buf)
+	for i, xd := range x.digits {
+		carry := uint8(0)
+		for j, yd := range y.digits {
+			prod := xd*yd + buf[i+j] + carry
+			buf[i+j] = prod % 10
+			carry = prod / 10
+		}
+		buf[i+len(y.digits)] = carry
+	}
+
+	z.commit(buf, x.scale+y.scale, x.negative != y.negative)
+	return z
+}
+
+// Neg sets z to -x and returns z. Like with BCD.Neg zero stays zero.
+func (z *Var) Neg(x *BCD) *Var {
+	z.Set(x)
+	if !z.IsZero() || z.negative {
+		z.negative = !z.negative
+	}
+	return z
+}
+
+// Abs sets z to |x| and returns z.
+func (z *Var) Abs(x *BCD) *Var {
+	z.Set(x)
+	z.negative = false
+	return z
+}
+
+// Round sets z to x rounded to the given decimal places and returns z.
+// The result is the same as of BCD.Round.
+func (z *Var) Round(x *BCD, places int, mode RoundingMode) *Var {
+	if x.IsNaN() {
+		return z.Set(x.Round(places, mode))
+	}
+	z.Set(x)
+	places = max(places, 0)
+	if z.form != finite || z.scale <= places {
+		return z
+	}
+
+	// Determine the rounding digit and if anything non-zero follows it
+	digits := z.digits
+	removeCount := z.scale - places
+	var roundDigit uint8
+	if removeCount-1 < len(digits) {
+		roundDigit = digits[removeCount-1]
+	}
+	sticky := !isZero(digits[:min(removeCount-1, len(digits))])
+
+	// Shift the kept digits down within the buffer
+	if removeCount < len(digits) {
+		n := copy(digits, digits[removeCount:])
+		digits = digits[:n]
+	} else {
+		digits = append(digits[:0], 0)
+	}
+
+	if shouldRoundUp(roundDigit, sticky, digits[0]%2 == 0, mode, z.negative) {
+		carry := uint8(1)
+		for i := 0; i < len(digits) && carry > 0; i++ {
+			sum := digits[i] + carry
+			digits[i] = sum % 10
+			carry = sum / 10
+		}
+		if carry > 0 {
+			digits = append(digits, carry)
+		}
+	}
+
+	z.digits = digits
+	z.trim(places, z.negative)
+	if len(z.digits) == 1 && z.digits[0] == 0 {
+		z.scale = 0
+		z.negative = false
+	}
+	return z
+}
+
+// add sets z to x + y with the sign of y replaced by yNegative. Both
+// operands are finite and aligned on the fly, so no copies are needed.
+func (z *Var) add(x, y *BCD, yNegative bool) *Var {
+	scale := max(x.scale, y.scale)
+	dx, dy := scale-x.scale, scale-y.scale
+
+	// Same signs add the magnitudes
+	if x.negative == yNegative {
+		n := max(len(x.digits)+dx, len(y.digits)+dy) + 1
+		buf := grow(z.scratch, n)
+		carry := uint8(0)
+		for i := range buf {
+			sum := digitAt(x, i-dx) + digitAt(y, i-dy) + carry
+			buf[i] = sum % 10
+			carry = sum / 10
+		}
+		z.commit(buf, scale, x.negative)
+		return z
+	}
+
+	// Different signs subtract the smaller from the larger magnitude
+	c := compareShifted(x, y, dx, dy)
+	if c == 0 {
+		z.digits = append(z.digits[:0], 0)
+		z.scale = 0
+		z.negative = false
+		z.form = finite
+		return z
+	}
+	negative := x.negative
+	if c < 0 {
+		x, y, dx, dy = y, x, dy, dx
+		negative = yNegative
+	}
+	buf := grow(z.scratch, len(x.digits)+dx)
+	borrow := uint8(0)
+	for i := range buf {
+		sub := digitAt(y, i-dy) + borrow
+		d := digitAt(x, i-dx)
+		if d < sub {
+			buf[i] = d + 10 - sub
+			borrow = 1
+		} else {
+			buf[i] = d - sub
+			borrow = 0
+		}
+	}
+	z.commit(buf, scale, negative)
+	return z
+}
+
+// commit makes buf the digits of z and keeps the former digits as
+// scratch buffer for the next operation.
+func (z *Var) commit(buf []uint8, scale int, negative bool) {
+	z.scratch = z.digits[:0]
+	z.digits = buf
+	z.form = finite
+	z.trim(scale, negative)
+}
+
+// trim removes leading zeros and sets scale and sign.
+func (z *Var) trim(scale int, negative bool) {
+	n := len(z.digits)
+	for n > 1 && z.digits[n-1] == 0 {
+		n--
+	}
+	z.digits = z.digits[:n]
+	z.scale = scale
+	z.negative = negative
+}
+
+// grow returns buf with length n, allocating only if the capacity
+// is too small.
+func grow(buf []uint8, n int) []uint8 {
+	if cap(buf) < n {
+		return make([]uint8, n, n+n/2)
+	}
+	return buf[:n]
+}
+
+// digitAt returns the digit at index i or 0 outside of the digits.
+func digitAt(b *BCD, i int) uint8 {
+	if i < 0 || i >= len(b.digits) {
+		return 0
+	}
+	return b.digits[i]
+}
+
+// compareShifted compares the magnitudes of x and y shifted by dx and
+// dy digits without creating aligned copies.
+func compareShifted(x, y *BCD, dx, dy int) int {
+	lx, ly := significantLength(x.digits), significantLength(y.digits)
+	if lx > 0 {
+		lx += dx
+	}
+	if ly > 0 {
+		ly += dy
+	}
+	if lx != ly {
+		if lx > ly {
+			return 1
+		}
+		return -1
+	}
+	for i := lx - 1; i >= 0; i-- {
+		a, b := digitAt(x, i-dx), digitAt(y, i-dy)
+		if a != b {
+			if a > b {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// Sum is a builder adding up many values with the buffers of a Var, so
+// summing up a large number of rows allocates no memory once the buffers
+// are large enough. The zero value is an empty sum.
+type Sum struct {
+	total Var
+	count int
+}
+
+// NewSum creates an empty sum.
+func NewSum() *Sum {
+	return &Sum{}
+}
+
+// Add adds the values to the sum and returns it.
+func (s *Sum) Add(values ...*BCD) *Sum {
+	for _, v := range values {
+		s.total.Add(&s.total.BCD, v)
+		s.count++
+	}
+	return s
+}
+
+// Sub subtracts the values from the sum and returns it.
+func (s *Sum) Sub(values ...*BCD) *Sum {
+	for _, v := range values {
+		s.total.Sub(&s.total.BCD, v)
+		s.count++
+	}
+	return s
+}
+
+// Count returns the number of added and subtracted values.
+func (s *Sum) Count() int {
+	return s.count
+}
+
+// Result returns the current sum as immutable BCD.
+func (s *Sum) Result() *BCD {
+	if s.total.digits == nil {
+		return Zero()
+	}
+	return s.total.Copy()
+}
+
+// Reset sets the sum back to zero keeping the buffers.
+func (s *Sum) Reset() {
+	s.total.SetInt64(0)
+	s.count = 0
+}
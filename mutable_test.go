@@ -0,0 +1,143 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// mutableOperands are combined pairwise to compare Var with the
+// immutable operations.
+var mutableOperands = []string{
+	"0", "-0", "1", "-1", "0.5", "-0.5", "9.99", "-0.001", "123.456",
+	"99999999999999999999", "-0.00000000000000000001", "1000", "NaN", "Inf", "-Inf",
+}
+
+func TestVarMatchesImmutable(t *testing.T) {
+	z := &Var{}
+	for _, a := range mutableOperands {
+		for _, b := range mutableOperands {
+			x, y := Must(a), Must(b)
+			verify.Equal(t, z.Add(x, y).String(), x.Add(y).String(), a+" + "+b)
+			verify.Equal(t, z.Sub(x, y).String(), x.Sub(y).String(), a+" - "+b)
+			verify.Equal(t, z.Mul(x, y).String(), x.Mul(y).String(), a+" * "+b)
+		}
+		x := Must(a)
+		verify.Equal(t, z.Neg(x).String(), x.Neg().String(), "-"+a)
+		verify.Equal(t, z.Abs(x).String(), x.Abs().String(), "|"+a+"|")
+		for places := range 4 {
+			for _, mode := range []RoundingMode{RoundDown, RoundUp, RoundHalfEven, RoundFloor} {
+				verify.Equal(t, z.Round(x, places, mode).String(), x.Round(places, mode).String(), a)
+			}
+		}
+	}
+}
+
+func TestVarAliasing(t *testing.T) {
+	z := NewVar(Must("1.5"))
+	z.Add(&z.BCD, Must("0.25"))
+	verify.Equal(t, z.String(), "1.75")
+	z.Mul(&z.BCD, &z.BCD)
+	verify.Equal(t, z.String(), "3.0625")
+	z.Sub(Must(10), &z.BCD)
+	verify.Equal(t, z.String(), "6.9375")
+	z.Round(&z.BCD, 2, RoundHalfEven)
+	verify.Equal(t, z.String(), "6.94")
+	z.Neg(&z.BCD)
+	verify.Equal(t, z.String(), "-6.94")
+
+	// Copies are not affected by later operations
+	c := z.Copy()
+	z.SetInt64(-9223372036854775808)
+	verify.Equal(t, c.String(), "-6.94")
+	verify.Equal(t, z.String(), "-9223372036854775808")
+
+	var zero Var
+	verify.Equal(t, zero.String(), "0")
+	verify.Equal(t, zero.Add(&zero.BCD, Must(2)).String(), "2")
+}
+
+func TestSum(t *testing.T) {
+	s := NewSum()
+	verify.Equal(t, s.Result().String(), "0")
+	s.Add(Must("10.50"), Must("-0.25"), Must("3"))
+	s.Sub(Must("0.25"))
+	verify.Equal(t, s.Result().String(), "13.00")
+	verify.Equal(t, s.Count(), 4)
+
+	s.Reset()
+	verify.Equal(t, s.Result().String(), "0")
+	verify.Equal(t, s.Count(), 0)
+}
+
+func TestVarZeroAllocations(t *testing.T) {
+	rows := ledgerRows(100)
+	s := NewSum()
+	s.Add(rows...)
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, row := range rows {
+			s.Add(row)
+		}
+	})
+	verify.Equal(t, allocs, 0.0)
+
+	z := NewVar(Must("1234.56"))
+	rate := Must("1.0725")
+	allocs = testing.AllocsPerRun(100, func() {
+		z.SetInt64(123456)
+		z.Mul(&z.BCD, rate)
+		z.Round(&z.BCD, 2, RoundHalfEven)
+		z.Sub(&z.BCD, rate)
+	})
+	verify.Equal(t, allocs, 0.0)
+}
+
+// ledgerRows returns n amounts with two decimal places.
+func ledgerRows(n int) []*BCD {
+	rows := make([]*BCD, n)
+	for i := range rows {
+		row := NewVar(Zero()).SetInt64(int64(i*7919%100000 - 50000))
+		row.scale = 2
+		rows[i] = row.Copy()
+	}
+	return rows
+}
+
+func BenchmarkSumImmutable(b *testing.B) {
+	rows := ledgerRows(1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		total := Zero()
+		for _, row := range rows {
+			total = total.Add(row)
+		}
+	}
+}
+
+func BenchmarkSumBuilder(b *testing.B) {
+	rows := ledgerRows(1000)
+	s := NewSum()
+	b.ReportAllocs()
+	for b.Loop() {
+		s.Reset()
+		s.Add(rows...)
+	}
+}
+
+func BenchmarkVarMulRound(b *testing.B) {
+	z := &Var{}
+	x := Must("1234.56")
+	rate := Must("1.0725")
+	b.ReportAllocs()
+	for b.Loop() {
+		z.Mul(x, rate)
+		z.Round(&z.BCD, 2, RoundHalfEven)
+	}
+}
@@ -0,0 +1,143 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import "fmt"
+
+// Limits of the MySQL DECIMAL type and its packing of nine digits
+// into four bytes.
+const (
+	mysqlMaxPrecision = 65
+	mysqlMaxScale     = 30
+	mysqlWordDigits   = 9
+	mysqlWordBytes    = 4
+)
+
+// mysqlDigitBytes contains the bytes needed for 0 to 9 digits.
+var mysqlDigitBytes = [mysqlWordDigits + 1]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+
+// MySQLDecimalSize returns the number of bytes of a MySQL DECIMAL with
+// the given precision and scale in its binary format, e.g. to split the
+// row images of a binlog.
+func MySQLDecimalSize(precision, scale int) (int, error) {
+	groups, err := mysqlGroups(precision, scale)
+	if err != nil {
+		return 0, err
+	}
+	size := 0
+	for _, g := range groups {
+		size += mysqlDigitBytes[g]
+	}
+	return size, nil
+}
+
+// EncodeMySQLDecimal returns the value in the binary format of a MySQL
+// DECIMAL with the given precision and scale as stored in InnoDB rows
+// and binlogs. Integer and fractional digits are packed separately in
+// groups of nine digits into four big-endian bytes, leftover digits
+// into fewer bytes. Negative values have all bytes inverted and the
+// highest bit of the first byte is flipped. The value is rounded to the
+// scale with the given mode, values exceeding the precision return
+// ErrOverflow.
+func (b *BCD) EncodeMySQLDecimal(precision, scale int, mode RoundingMode) ([]byte, error) {
+	groups, err := mysqlGroups(precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	digits, negative, err := b.unscaledDigits(precision, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	var mask byte
+	if negative {
+		mask = 0xff
+	}
+	data := make([]byte, 0, mysqlWordBytes*len(groups))
+	pos := precision
+	for _, g := range groups {
+		// Collect the group from its most significant digit
+		var v uint32
+		for range g {
+			pos--
+			v *= 10
+			if pos < len(digits) {
+				v += uint32(digits[pos])
+			}
+		}
+		for i := mysqlDigitBytes[g] - 1; i >= 0; i-- {
+			data = append(data, byte(v>>(8*i))^mask)
+		}
+	}
+	data[0] ^= 0x80
+	return data, nil
+}
+
+// DecodeMySQLDecimal creates a BCD from the binary format of a MySQL
+// DECIMAL with the given precision and scale. The length of the data
+// has to match MySQLDecimalSize.
+func DecodeMySQLDecimal(data []byte, precision, scale int) (*BCD, error) {
+	size, err := MySQLDecimalSize(precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != size {
+		return nil, fmt.Errorf("%w: DECIMAL(%d,%d) needs %d bytes, got %d", ErrInvalidFormat, precision, scale, size, len(data))
+	}
+	groups, _ := mysqlGroups(precision, scale)
+	negative := data[0]&0x80 == 0
+	var mask byte
+	if negative {
+		mask = 0xff
+	}
+	digits := make([]uint8, precision)
+	pos, off := precision, 0
+	for _, g := range groups {
+		var v uint32
+		for i := range mysqlDigitBytes[g] {
+			c := data[off+i] ^ mask
+			if off+i == 0 {
+				c ^= 0x80
+			}
+			v = v<<8 | uint32(c)
+		}
+		off += mysqlDigitBytes[g]
+		if v >= uint32(powersOfTen[g]) {
+			return nil, fmt.Errorf("%w: DECIMAL group %d exceeds %d digits", ErrInvalidFormat, v, g)
+		}
+		pos -= g
+		for i := range g {
+			digits[pos+i] = uint8(v % 10)
+			v /= 10
+		}
+	}
+	return fromUnscaledDigits(digits, negative, scale), nil
+}
+
+// mysqlGroups returns the digit counts of the groups from the most
+// significant one. The leftover integer digits come first and the
+// leftover fractional digits last.
+func mysqlGroups(precision, scale int) ([]int, error) {
+	if precision < 1 || precision > mysqlMaxPrecision || scale < 0 || scale > mysqlMaxScale || scale > precision {
+		return nil, fmt.Errorf("%w: invalid DECIMAL(%d,%d)", ErrInvalidOperation, precision, scale)
+	}
+	intg := precision - scale
+	var groups []int
+	if intg%mysqlWordDigits > 0 {
+		groups = append(groups, intg%mysqlWordDigits)
+	}
+	for range intg / mysqlWordDigits {
+		groups = append(groups, mysqlWordDigits)
+	}
+	for range scale / mysqlWordDigits {
+		groups = append(groups, mysqlWordDigits)
+	}
+	if scale%mysqlWordDigits > 0 {
+		groups = append(groups, scale%mysqlWordDigits)
+	}
+	return groups, nil
+}
@@ -0,0 +1,81 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestMySQLDecimal(t *testing.T) {
+	tests := []struct {
+		value     string
+		precision int
+		scale     int
+		mode      RoundingMode
+		bytes     string
+		back      string
+	}{
+		// Sample of decimal2bin in the MySQL sources
+		{"1234567890.1234", 14, 4, RoundHalfEven, "810dfb38d204d2", "1234567890.1234"},
+		{"-1234567890.1234", 14, 4, RoundHalfEven, "7ef204c72dfb2d", "-1234567890.1234"},
+		{"1.5", 10, 2, RoundHalfEven, "8000000132", "1.50"},
+		{"-1.5", 10, 2, RoundHalfEven, "7ffffffecd", "-1.50"},
+		{"0", 10, 2, RoundHalfEven, "8000000000", "0"},
+		{"1.5", 18, 9, RoundHalfEven, "800000011dcd6500", "1.500000000"},
+		{"1.005", 5, 2, RoundHalfEven, "800100", "1.00"},
+		{"1.005", 5, 2, RoundHalfUp, "800101", "1.01"},
+		{"-0.001", 5, 2, RoundHalfEven, "800000", "0"},
+	}
+	for _, test := range tests {
+		data, err := Must(test.value).EncodeMySQLDecimal(test.precision, test.scale, test.mode)
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.bytes, test.value)
+
+		back, err := DecodeMySQLDecimal(data, test.precision, test.scale)
+		verify.NoError(t, err)
+		verify.Equal(t, back.String(), test.back, test.value)
+	}
+}
+
+func TestMySQLDecimalSize(t *testing.T) {
+	tests := []struct {
+		precision int
+		scale     int
+		size      int
+	}{
+		{1, 0, 1},
+		{10, 2, 5},
+		{14, 4, 7},
+		{18, 9, 8},
+		{65, 30, 30},
+	}
+	for _, test := range tests {
+		size, err := MySQLDecimalSize(test.precision, test.scale)
+		verify.NoError(t, err)
+		verify.Equal(t, size, test.size)
+	}
+}
+
+func TestMySQLDecimalErrors(t *testing.T) {
+	_, err := Must("1000").EncodeMySQLDecimal(5, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("1").EncodeMySQLDecimal(66, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Must("1").EncodeMySQLDecimal(10, 31, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = NaN().EncodeMySQLDecimal(10, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	_, err = DecodeMySQLDecimal([]byte{0x80, 0x00}, 10, 2)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = DecodeMySQLDecimal([]byte{0xbb, 0x9a, 0xca, 0x00}, 9, 0)
+	verify.IsError(t, err, ErrInvalidFormat)
+}
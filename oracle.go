@@ -0,0 +1,171 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import "fmt"
+
+// Markers and limits of the Oracle NUMBER format.
+const (
+	oracleZero         = 0x80
+	oracleExponentBias = 0xc1
+	oracleNegativeBias = 0x3e
+	oracleTerminator   = 0x66
+	oracleMaxMantissa  = 20
+	oracleMaxPrecision = 38
+	oracleMinExponent  = -64
+	oracleMaxExponent  = 62
+	oracleMinScale     = -84
+	oracleMaxScale     = 127
+)
+
+// EncodeOracleNumber returns the value in the internal format of the
+// Oracle NUMBER type. The first byte contains the sign and the excess-64
+// exponent of base 100, followed by up to 20 base 100 digits stored as
+// digit+1. Negative values have the exponent byte complemented, the
+// digits stored as 101-digit, and a terminating 102 if there are less
+// than 20 digits. Zero is a single 0x80, positive infinity 0xff 0x65,
+// and negative infinity a single 0x00.
+//
+// A precision of 0 encodes the value like an unconstrained NUMBER and
+// returns ErrPrecisionLoss if it needs more than 20 base 100 digits.
+// Otherwise the value is rounded to the scale with the given mode like
+// NUMBER(precision, scale), values exceeding the precision return
+// ErrOverflow. Like in Oracle the scale ranges from -84 to 127, so it
+// may round to the left of the decimal point or exceed the precision,
+// e.g. NUMBER(7,-2) stores 1234567 as 1234600 and NUMBER(4,5) stores
+// 0.01234.
+func (b *BCD) EncodeOracleNumber(precision, scale int, mode RoundingMode) ([]byte, error) {
+	switch {
+	case b.form == infinite && b.negative:
+		return []byte{0x00}, nil
+	case b.form == infinite:
+		return []byte{0xff, 0x65}, nil
+	case !b.IsFinite():
+		return nil, fmt.Errorf("%w: Oracle NUMBER has no NaN", ErrInvalidOperation)
+	case precision > oracleMaxPrecision:
+		return nil, fmt.Errorf("%w: precision %d exceeds %d", ErrInvalidOperation, precision, oracleMaxPrecision)
+	}
+	r := b
+	if precision != 0 {
+		var err error
+		if r, err = b.oracleRescale(precision, scale, mode); err != nil {
+			return nil, err
+		}
+	}
+	n := significantLength(r.digits)
+	if n == 0 {
+		return []byte{oracleZero}, nil
+	}
+	low := 0
+	for r.digits[low] == 0 {
+		low++
+	}
+
+	// Pairs cover the decimal exponents 2*k and 2*k+1.
+	top := floorDiv(n-1-r.scale, 2)
+	lowest := floorDiv(low-r.scale, 2)
+	if top < oracleMinExponent || top > oracleMaxExponent {
+		return nil, fmt.Errorf("%w: %s exceeds Oracle NUMBER range", ErrOverflow, b)
+	}
+	if top-lowest >= oracleMaxMantissa {
+		return nil, fmt.Errorf("%w: %s exceeds %d base 100 digits", ErrPrecisionLoss, b, oracleMaxMantissa)
+	}
+	data := make([]byte, 0, top-lowest+3)
+	if r.negative {
+		data = append(data, byte(oracleNegativeBias-top))
+	} else {
+		data = append(data, byte(oracleExponentBias+top))
+	}
+	for k := top; k >= lowest; k-- {
+		d := 10*digitAt(r, 2*k+1+r.scale) + digitAt(r, 2*k+r.scale)
+		if r.negative {
+			data = append(data, 101-d)
+		} else {
+			data = append(data, d+1)
+		}
+	}
+	if r.negative && len(data) <= oracleMaxMantissa {
+		data = append(data, oracleTerminator)
+	}
+	return data, nil
+}
+
+// oracleRescale rounds the finite value to the scale of NUMBER(precision,
+// scale) and checks that it is below 10^(precision-scale).
+func (b *BCD) oracleRescale(precision, scale int, mode RoundingMode) (*BCD, error) {
+	if precision < 1 || scale < oracleMinScale || scale > oracleMaxScale {
+		return nil, fmt.Errorf("%w: invalid precision %d and scale %d", ErrInvalidOperation, precision, scale)
+	}
+	var r *BCD
+	if scale >= 0 {
+		r = b.Round(scale, mode)
+	} else {
+		// Round the value divided by 10^-scale and multiply it again
+		shifted := b.Copy()
+		shifted.scale -= scale
+		r = applyExponent(shifted.Round(0, mode), -scale)
+	}
+	if n := significantLength(r.digits); n > 0 && n-r.scale > precision-scale {
+		return nil, fmt.Errorf("%w: %s exceeds precision %d with scale %d", ErrOverflow, b, precision, scale)
+	}
+	return r, nil
+}
+
+// DecodeOracleNumber creates a BCD from the internal format of the
+// Oracle NUMBER type. The result has the smallest scale keeping all
+// digits.
+func DecodeOracleNumber(data []byte) (*BCD, error) {
+	switch {
+	case len(data) == 0:
+		return nil, fmt.Errorf("%w: empty Oracle NUMBER", ErrInvalidFormat)
+	case len(data) == 1 && data[0] == oracleZero:
+		return Zero(), nil
+	case len(data) == 1 && data[0] == 0x00:
+		return Inf(-1), nil
+	case len(data) == 2 && data[0] == 0xff && data[1] == 0x65:
+		return Inf(1), nil
+	}
+	negative := data[0] < oracleZero
+	mantissa := data[1:]
+	var top int
+	if negative {
+		top = oracleNegativeBias - int(data[0])
+		if len(mantissa) > 0 && mantissa[len(mantissa)-1] == oracleTerminator {
+			mantissa = mantissa[:len(mantissa)-1]
+		}
+	} else {
+		top = int(data[0]) - oracleExponentBias
+	}
+	if len(mantissa) == 0 || len(mantissa) > oracleMaxMantissa {
+		return nil, fmt.Errorf("%w: Oracle NUMBER with %d digits", ErrInvalidFormat, len(mantissa))
+	}
+
+	// Spread the pairs to digits from the lowest exponent upwards.
+	lowest := top - len(mantissa) + 1
+	scale := max(0, -2*lowest)
+	digits := make([]uint8, 2*top+2+scale)
+	for i, m := range mantissa {
+		d := int(m) - 1
+		if negative {
+			d = 101 - int(m)
+		}
+		if d < 0 || d > 99 {
+			return nil, fmt.Errorf("%w: Oracle NUMBER digit byte 0x%02x", ErrInvalidFormat, m)
+		}
+		idx := 2*(top-i) + scale
+		digits[idx] = uint8(d % 10)
+		digits[idx+1] = uint8(d / 10)
+	}
+
+	// Drop trailing fractional zeros like the parser.
+	for scale > 0 && len(digits) > 1 && digits[0] == 0 {
+		digits = digits[1:]
+		scale--
+	}
+	return fromUnscaledDigits(digits, negative, scale), nil
+}
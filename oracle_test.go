@@ -0,0 +1,119 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestOracleNumber(t *testing.T) {
+	// Vectors of SELECT DUMP(n, 16) for unconstrained NUMBER values
+	tests := []struct {
+		value string
+		bytes string
+	}{
+		{"0", "80"},
+		{"1", "c102"},
+		{"100", "c202"},
+		{"123", "c20218"},
+		{"0.01", "c002"},
+		{"1.5", "c10233"},
+		{"123.456", "c202182e3d"},
+		{"-1", "3e6466"},
+		{"-100", "3d6466"},
+		{"-1.5", "3e643366"},
+		{"-123.456", "3d644e382966"},
+		{"1" + strings.Repeat("0", 125), "ff0b"},
+		{"0." + strings.Repeat("0", 127) + "1", "8102"},
+		{"Inf", "ff65"},
+		{"-Inf", "00"},
+	}
+	for _, test := range tests {
+		data, err := Must(test.value).EncodeOracleNumber(0, 0, RoundHalfEven)
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.bytes, test.value)
+
+		back, err := DecodeOracleNumber(data)
+		verify.NoError(t, err)
+		verify.Equal(t, back.String(), test.value, test.bytes)
+	}
+
+	// Twenty digits of a negative value have no terminator.
+	value := "-1234567890123456789012345678901234567890"
+	data, err := Must(value).EncodeOracleNumber(0, 0, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, len(data), 21)
+	back, err := DecodeOracleNumber(data)
+	verify.NoError(t, err)
+	verify.Equal(t, back.String(), value)
+}
+
+func TestOracleNumberPrecision(t *testing.T) {
+	data, err := Must("1.005").EncodeOracleNumber(5, 2, RoundHalfUp)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "c10202")
+	data, err = Must("-0.004").EncodeOracleNumber(5, 2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "80")
+
+	// Negative scales round to the left of the decimal point, scales
+	// beyond the precision allow only small values
+	for _, test := range []struct {
+		value     string
+		precision int
+		scale     int
+		want      string
+	}{
+		{"1234567", 7, -2, "1234600"},
+		{"-1234550", 7, -2, "-1234600"},
+		{"999999949.9", 7, -2, "999999900"},
+		{"49", 7, -2, "0"},
+		{"0.01234", 4, 5, "0.01234"},
+		{"-0.012345", 4, 5, "-0.01234"},
+		{"0.000001", 4, 5, "0"},
+	} {
+		data, err := Must(test.value).EncodeOracleNumber(test.precision, test.scale, RoundHalfEven)
+		verify.NoError(t, err)
+		back, err := DecodeOracleNumber(data)
+		verify.NoError(t, err)
+		verify.Equal(t, back.String(), test.want, test.value)
+	}
+	_, err = Must("999999950").EncodeOracleNumber(7, -2, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("0.1").EncodeOracleNumber(4, 5, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("1").EncodeOracleNumber(5, -85, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Must("1").EncodeOracleNumber(5, 128, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	_, err = Must("1000").EncodeOracleNumber(5, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("1").EncodeOracleNumber(39, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Must("1"+strings.Repeat("0", 126)).EncodeOracleNumber(0, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+	_, err = Must("1."+strings.Repeat("1", 40)).EncodeOracleNumber(0, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrPrecisionLoss)
+	_, err = NaN().EncodeOracleNumber(0, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestOracleNumberErrors(t *testing.T) {
+	tests := []string{"", "c1", "c165", "c100", "3e01", "3e66"}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test)
+		verify.NoError(t, err)
+		_, err = DecodeOracleNumber(data)
+		verify.IsError(t, err, ErrInvalidFormat)
+	}
+}
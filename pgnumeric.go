@@ -0,0 +1,155 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Sign words and limits of the PostgreSQL NUMERIC binary format.
+const (
+	pgNumericPositive = 0x0000
+	pgNumericNegative = 0x4000
+	pgNumericNaN      = 0xc000
+	pgNumericPosInf   = 0xd000
+	pgNumericNegInf   = 0xf000
+	pgNumericMaxScale = 0x3fff
+	pgNumericBase     = 10000
+	pgNumericGroup    = 4
+)
+
+// EncodePGNumeric returns the value in the binary format of the PostgreSQL
+// NUMERIC type as used by COPY BINARY and the binary wire protocol. It
+// consists of the number of base 10000 digit groups, the weight of the
+// first group, the sign, and the display scale, all as big-endian 16 bit
+// words, followed by the groups. The display scale is the scale of the
+// value. Infinities and quiet NaNs are supported, signaling NaNs return
+// ErrInvalidOperation.
+func (b *BCD) EncodePGNumeric() ([]byte, error) {
+	switch b.form {
+	case quietNaN:
+		return appendPGHeader(nil, 0, 0, pgNumericNaN, 0), nil
+	case infinite:
+		if b.negative {
+			return appendPGHeader(nil, 0, 0, pgNumericNegInf, 0), nil
+		}
+		return appendPGHeader(nil, 0, 0, pgNumericPosInf, 0), nil
+	case signalingNaN:
+		return nil, fmt.Errorf("%w: PostgreSQL NUMERIC has no signaling NaN", ErrInvalidOperation)
+	}
+	if b.scale > pgNumericMaxScale {
+		return nil, fmt.Errorf("%w: scale %d exceeds NUMERIC display scale", ErrOverflow, b.scale)
+	}
+	n := significantLength(b.digits)
+	if n == 0 {
+		return appendPGHeader(nil, 0, 0, pgNumericPositive, b.scale), nil
+	}
+	low := 0
+	for b.digits[low] == 0 {
+		low++
+	}
+
+	// Groups cover the decimal exponents 4*k up to 4*k+3.
+	weight := floorDiv(n-1-b.scale, pgNumericGroup)
+	lowest := floorDiv(low-b.scale, pgNumericGroup)
+	if weight > math.MaxInt16 || lowest < math.MinInt16 || weight-lowest >= math.MaxInt16 {
+		return nil, fmt.Errorf("%w: %s exceeds NUMERIC range", ErrOverflow, b)
+	}
+	sign := pgNumericPositive
+	if b.negative {
+		sign = pgNumericNegative
+	}
+	data := appendPGHeader(make([]byte, 0, 8+2*(weight-lowest+1)), weight-lowest+1, weight, sign, b.scale)
+	for k := weight; k >= lowest; k-- {
+		group := 0
+		for j := pgNumericGroup - 1; j >= 0; j-- {
+			group = group*10 + int(digitAt(b, pgNumericGroup*k+j+b.scale))
+		}
+		data = binary.BigEndian.AppendUint16(data, uint16(group))
+	}
+	return data, nil
+}
+
+// DecodePGNumeric creates a BCD from the binary format of the PostgreSQL
+// NUMERIC type. The scale of the result is the display scale, digits
+// beyond it return ErrInvalidFormat.
+func DecodePGNumeric(data []byte) (*BCD, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("%w: NUMERIC too short", ErrInvalidFormat)
+	}
+	ndigits := int(int16(binary.BigEndian.Uint16(data[0:])))
+	weight := int(int16(binary.BigEndian.Uint16(data[2:])))
+	sign := binary.BigEndian.Uint16(data[4:])
+	dscale := int(binary.BigEndian.Uint16(data[6:]))
+	if ndigits < 0 || len(data) != 8+2*ndigits {
+		return nil, fmt.Errorf("%w: NUMERIC length %d for %d digits", ErrInvalidFormat, len(data), ndigits)
+	}
+	switch sign {
+	case pgNumericNaN:
+		return NaN(), nil
+	case pgNumericPosInf:
+		return Inf(1), nil
+	case pgNumericNegInf:
+		return Inf(-1), nil
+	case pgNumericPositive, pgNumericNegative:
+	default:
+		return nil, fmt.Errorf("%w: NUMERIC sign 0x%04x", ErrInvalidFormat, sign)
+	}
+	if dscale > pgNumericMaxScale {
+		return nil, fmt.Errorf("%w: NUMERIC display scale %d", ErrInvalidFormat, dscale)
+	}
+	if ndigits == 0 {
+		return &BCD{digits: []uint8{0}, scale: dscale}, nil
+	}
+
+	// Spread the groups to digits from the exponent -dscale upwards.
+	top := pgNumericGroup*weight + pgNumericGroup - 1
+	if top < -dscale {
+		return nil, fmt.Errorf("%w: NUMERIC digits beyond display scale %d", ErrInvalidFormat, dscale)
+	}
+	digits := make([]uint8, top+dscale+1)
+	for i := range ndigits {
+		group := binary.BigEndian.Uint16(data[8+2*i:])
+		if group >= pgNumericBase {
+			return nil, fmt.Errorf("%w: NUMERIC digit %d", ErrInvalidFormat, group)
+		}
+		k := weight - i
+		for j := range pgNumericGroup {
+			d := uint8(group % 10)
+			group /= 10
+			idx := pgNumericGroup*k + j + dscale
+			if idx < 0 {
+				if d != 0 {
+					return nil, fmt.Errorf("%w: NUMERIC digits beyond display scale %d", ErrInvalidFormat, dscale)
+				}
+				continue
+			}
+			digits[idx] = d
+		}
+	}
+	return fromUnscaledDigits(digits, sign == pgNumericNegative, dscale), nil
+}
+
+// appendPGHeader appends the four header words of a NUMERIC.
+func appendPGHeader(dst []byte, ndigits, weight, sign, dscale int) []byte {
+	dst = binary.BigEndian.AppendUint16(dst, uint16(ndigits))
+	dst = binary.BigEndian.AppendUint16(dst, uint16(int16(weight)))
+	dst = binary.BigEndian.AppendUint16(dst, uint16(sign))
+	return binary.BigEndian.AppendUint16(dst, uint16(dscale))
+}
+
+// floorDiv returns a divided by b rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
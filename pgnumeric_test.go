@@ -0,0 +1,92 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestPGNumeric(t *testing.T) {
+	// Vectors of numeric_send, e.g. SELECT numeric_send('1.50').
+	tests := []struct {
+		bytes string
+		value string
+		scale int
+	}{
+		{"0000000000000000", "0", 0},
+		{"0000000000000002", "0", 2},
+		{"0002000000000002" + "00011388", "1.50", 2},
+		{"0003000100000003" + "000109291a7c", "12345.678", 3},
+		{"0001ffff40000004" + "0001", "-0.0001", 4},
+		{"0001000100000000" + "0001", "10000", 0},
+		{"0001fffe00000008" + "04d2", "0.00001234", 8},
+		{"0005000240000005" + "000c0d801ed204d21388", "-1234567890.12345", 5},
+		{"00000000c0000000", "NaN", 0},
+		{"00000000d0000000", "Inf", 0},
+		{"00000000f0000000", "-Inf", 0},
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test.bytes)
+		verify.NoError(t, err)
+		value, err := DecodePGNumeric(data)
+		verify.NoError(t, err)
+		verify.Equal(t, value.String(), test.value, test.bytes)
+		verify.Equal(t, value.Scale(), test.scale, test.bytes)
+
+		back, err := value.EncodePGNumeric()
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(back), test.bytes, test.value)
+	}
+}
+
+func TestPGNumericScale(t *testing.T) {
+	// The display scale is the scale of the value.
+	tests := []struct {
+		value *BCD
+		bytes string
+	}{
+		{Must("1.5"), "0002000000000001" + "00011388"},
+		{Must("0.05"), "0001ffff00000002" + "01f4"},
+		{Must("-0"), "0000000000000000"},
+		{Must("123456789"), "0003000200000000" + "000109291a85"},
+	}
+	for _, test := range tests {
+		data, err := test.value.EncodePGNumeric()
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.bytes, test.value.String())
+	}
+	value, err := FromUnscaledInt64(1500, 3)
+	verify.NoError(t, err)
+	data, err := value.EncodePGNumeric()
+	verify.NoError(t, err)
+	verify.Equal(t, hex.EncodeToString(data), "0002000000000003"+"00011388")
+}
+
+func TestPGNumericErrors(t *testing.T) {
+	_, err := SignalingNaN().EncodePGNumeric()
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	tests := []string{
+		"00000000000000",
+		"0001000000000000",
+		"000100000000000000",
+		"0001000000000000" + "2710",
+		"0000000012340000",
+		"0000000000004000",
+		"0001ffff00000002" + "0001",
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test)
+		verify.NoError(t, err)
+		_, err = DecodePGNumeric(data)
+		verify.IsError(t, err, ErrInvalidFormat)
+	}
+}
@@ -0,0 +1,394 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rat represents an exact rational number as the quotient of two BCD
+// integers. It is always kept normalized: numerator and denominator
+// share no common factor and the denominator is positive. So chained
+// divisions like 1/3 * 3 stay exact until they are converted back to
+// a BCD with a given scale.
+type Rat struct {
+	num *BCD
+	den *BCD
+}
+
+// NewRat creates the rational number num / den. Both values may have
+// decimal places, e.g. 1.5 / 0.25 is normalized to 6. Infinities and
+// NaNs return ErrInvalidOperation.
+func NewRat(num, den *BCD) (*Rat, error) {
+	if !num.IsFinite() || !den.IsFinite() {
+		return nil, ErrInvalidOperation
+	}
+	if den.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+
+	// Remove the decimal places by scaling both values
+	n := &BCD{digits: num.digits, negative: num.negative != den.negative}
+	d := &BCD{digits: den.digits}
+	if num.scale > den.scale {
+		d = d.Mul(pow10(num.scale - den.scale))
+	} else if den.scale > num.scale {
+		n = n.Mul(pow10(den.scale - num.scale))
+	}
+
+	return newNormalizedRat(n, d), nil
+}
+
+// MustRat creates the rational number num / den and panics on error.
+func MustRat(num, den *BCD) *Rat {
+	r, err := NewRat(num, den)
+	if err != nil {
+		panic(fmt.Sprintf("bcd.MustRat: %v", err))
+	}
+	return r
+}
+
+// RatFromBCD creates the rational number representing b exactly.
+// Infinities and NaNs return ErrInvalidOperation.
+func RatFromBCD(b *BCD) (*Rat, error) {
+	return NewRat(b, fromInt64(1))
+}
+
+// ParseRat parses a rational number. Supported are integers and
+// decimals ("-2", "1.25"), fractions ("3/4", "-3/4"), mixed fractions
+// ("1 1/2", "-1 1/2") and repeating decimals ("0.(3)", "1.1(6)").
+func ParseRat(s string) (*Rat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, ErrInvalidFormat
+	}
+
+	negative := false
+	unsigned := s
+	if strings.HasPrefix(unsigned, "-") {
+		negative = true
+		unsigned = unsigned[1:]
+	} else if strings.HasPrefix(unsigned, "+") {
+		unsigned = unsigned[1:]
+	}
+
+	var r *Rat
+	var err error
+	switch {
+	case strings.Contains(unsigned, "("):
+		r, err = parseRepeating(unsigned)
+	case strings.Contains(unsigned, "/"):
+		r, err = parseFraction(unsigned)
+	default:
+		var b *BCD
+		b, err = parseString(unsigned)
+		if err == nil {
+			r, err = RatFromBCD(b)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFormat, s)
+	}
+
+	if negative {
+		r = r.Neg()
+	}
+	return r, nil
+}
+
+// parseFraction parses an unsigned fraction with an optional whole
+// number part like "3/4" or "1 1/2".
+func parseFraction(s string) (*Rat, error) {
+	whole := Zero()
+	if idx := strings.LastIndex(s, " "); idx >= 0 {
+		w, err := parseUnsignedInteger(strings.TrimSpace(s[:idx]))
+		if err != nil {
+			return nil, err
+		}
+		whole = w
+		s = s[idx+1:]
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, ErrInvalidFormat
+	}
+	num, err := parseUnsignedInteger(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	den, err := parseUnsignedInteger(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if den.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+
+	return newNormalizedRat(whole.Mul(den).Add(num), den), nil
+}
+
+// parseRepeating parses an unsigned repeating decimal like "0.1(6)".
+func parseRepeating(s string) (*Rat, error) {
+	open := strings.Index(s, "(")
+	if !strings.HasSuffix(s, ")") || open < 0 {
+		return nil, ErrInvalidFormat
+	}
+	prefix := s[:open]
+	repetend := s[open+1 : len(s)-1]
+	dot := strings.Index(prefix, ".")
+	if dot < 0 || repetend == "" {
+		return nil, ErrInvalidFormat
+	}
+
+	// For x = I.F(R) with f digits in F and r digits in R it is
+	// x = (IFR - IF) / (10^f * (10^r - 1)).
+	fixed := prefix[:dot] + prefix[dot+1:]
+	if fixed == "" {
+		fixed = "0"
+	}
+	all, err := parseUnsignedInteger(fixed + repetend)
+	if err != nil {
+		return nil, err
+	}
+	head, err := parseUnsignedInteger(fixed)
+	if err != nil {
+		return nil, err
+	}
+	f := len(prefix) - dot - 1
+	r := len(repetend)
+	den := pow10(f).Mul(pow10(r).Sub(fromInt64(1)))
+
+	return newNormalizedRat(all.Sub(head), den), nil
+}
+
+// parseUnsignedInteger parses a string of decimal digits.
+func parseUnsignedInteger(s string) (*BCD, error) {
+	if s == "" {
+		return nil, ErrInvalidFormat
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return nil, ErrInvalidFormat
+		}
+	}
+	return parseString(s)
+}
+
+// newNormalizedRat creates a rational number from the integers num and
+// den, den must not be zero. The sign of den is moved to num and both
+// are divided by their greatest common divisor.
+func newNormalizedRat(num, den *BCD) *Rat {
+	negative := num.IsNegative() != den.IsNegative()
+	n := &BCD{digits: num.digits}
+	d := &BCD{digits: den.digits}
+
+	if n.IsZero() {
+		return &Rat{num: Zero(), den: fromInt64(1)}
+	}
+
+	g := gcd(n, d)
+	if !(len(g.digits) == 1 && g.digits[0] == 1) {
+		n, _ = divideIntegers(n, g)
+		d, _ = divideIntegers(d, g)
+	}
+	n.negative = negative
+
+	return &Rat{num: n, den: d}
+}
+
+// Num returns the numerator of the rational number.
+func (r *Rat) Num() *BCD {
+	return r.num.Copy()
+}
+
+// Denom returns the always positive denominator of the rational number.
+func (r *Rat) Denom() *BCD {
+	return r.den.Copy()
+}
+
+// IsZero returns true if the rational number is zero.
+func (r *Rat) IsZero() bool {
+	return r.num.IsZero()
+}
+
+// IsNegative returns true if the rational number is negative.
+func (r *Rat) IsNegative() bool {
+	return r.num.IsNegative()
+}
+
+// IsInteger returns true if the denominator is one.
+func (r *Rat) IsInteger() bool {
+	return len(r.den.digits) == 1 && r.den.digits[0] == 1
+}
+
+// Add returns r + other.
+func (r *Rat) Add(other *Rat) *Rat {
+	num := r.num.Mul(other.den).Add(other.num.Mul(r.den))
+	return newNormalizedRat(num, r.den.Mul(other.den))
+}
+
+// Sub returns r - other.
+func (r *Rat) Sub(other *Rat) *Rat {
+	return r.Add(other.Neg())
+}
+
+// Mul returns r * other.
+func (r *Rat) Mul(other *Rat) *Rat {
+	return newNormalizedRat(r.num.Mul(other.num), r.den.Mul(other.den))
+}
+
+// Div returns r / other.
+func (r *Rat) Div(other *Rat) (*Rat, error) {
+	if other.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+	return newNormalizedRat(r.num.Mul(other.den), r.den.Mul(other.num)), nil
+}
+
+// Inv returns 1 / r.
+func (r *Rat) Inv() (*Rat, error) {
+	if r.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+	return newNormalizedRat(r.den, r.num), nil
+}
+
+// Neg returns the negation of r.
+func (r *Rat) Neg() *Rat {
+	return &Rat{num: r.num.Neg(), den: r.den.Copy()}
+}
+
+// Abs returns the absolute value of r.
+func (r *Rat) Abs() *Rat {
+	return &Rat{num: r.num.Abs(), den: r.den.Copy()}
+}
+
+// Cmp compares two rational numbers and returns -1, 0, or 1.
+func (r *Rat) Cmp(other *Rat) int {
+	return r.num.Mul(other.den).Cmp(other.num.Mul(r.den))
+}
+
+// Equal returns true if r equals other.
+func (r *Rat) Equal(other *Rat) bool {
+	return r.Cmp(other) == 0
+}
+
+// ToBCD converts the rational number to a BCD with the specified scale
+// and rounding mode.
+func (r *Rat) ToBCD(scale int, mode RoundingMode) *BCD {
+	b, _ := r.num.Div(r.den, scale, mode)
+	return b
+}
+
+// IsTerminating returns true if the decimal expansion of r is finite,
+// which is the case if the denominator has no prime factors other than
+// 2 and 5. A terminating number converts to a BCD without loss.
+func (r *Rat) IsTerminating() bool {
+	d := r.den
+	for _, p := range []uint8{2, 5} {
+		for {
+			q, rem := divideBySmallInt(d, p)
+			if !rem.IsZero() {
+				break
+			}
+			d = q
+		}
+	}
+	return len(d.digits) == 1 && d.digits[0] == 1
+}
+
+// String returns the rational number as fraction like "-3/4", or as
+// plain integer if the denominator is one.
+func (r *Rat) String() string {
+	if r.IsInteger() {
+		return r.num.String()
+	}
+	return r.num.String() + "/" + r.den.String()
+}
+
+// MixedString returns the rational number as mixed fraction like "1 1/2".
+func (r *Rat) MixedString() string {
+	if r.IsInteger() {
+		return r.num.String()
+	}
+	whole, rem := divideIntegers(&BCD{digits: r.num.digits}, r.den)
+	frac := rem.String() + "/" + r.den.String()
+	sign := ""
+	if r.IsNegative() {
+		sign = "-"
+	}
+	if whole.IsZero() {
+		return sign + frac
+	}
+	return sign + whole.String() + " " + frac
+}
+
+// RepeatingString returns the decimal expansion of r with the repeating
+// part in parentheses, e.g. "0.(3)" for 1/3 or "0.58(3)" for 7/12. The
+// period can be as long as the denominator, so the expansion stops after
+// maxDigits fractional digits and ends with "..." in that case.
+func (r *Rat) RepeatingString(maxDigits int) string {
+	whole, rem := divideIntegers(&BCD{digits: r.num.digits}, r.den)
+
+	var sb strings.Builder
+	if r.IsNegative() {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(whole.String())
+	if rem.IsZero() {
+		return sb.String()
+	}
+	sb.WriteByte('.')
+
+	// Long division remembering the position of each remainder, a
+	// repeated remainder starts the repetend.
+	var fraction []byte
+	positions := map[string]int{}
+	for !rem.IsZero() {
+		key := rem.String()
+		if pos, ok := positions[key]; ok {
+			sb.Write(fraction[:pos])
+			sb.WriteByte('(')
+			sb.Write(fraction[pos:])
+			sb.WriteByte(')')
+			return sb.String()
+		}
+		if len(fraction) >= maxDigits {
+			sb.Write(fraction)
+			sb.WriteString("...")
+			return sb.String()
+		}
+		positions[key] = len(fraction)
+
+		var digit *BCD
+		shifted := &BCD{digits: append([]uint8{0}, rem.digits...)}
+		digit, rem = divideIntegers(shifted, r.den)
+		fraction = append(fraction, digit.digits[0]+'0')
+	}
+
+	sb.Write(fraction)
+	return sb.String()
+}
+
+// gcd returns the greatest common divisor of two positive BCD integers.
+func gcd(a, b *BCD) *BCD {
+	for !b.IsZero() {
+		_, rem := divideIntegers(a, b)
+		a, b = b, rem
+	}
+	return a
+}
+
+// pow10 returns 10^n as BCD integer.
+func pow10(n int) *BCD {
+	digits := make([]uint8, n+1)
+	digits[n] = 1
+	return &BCD{digits: digits}
+}
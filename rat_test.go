@@ -0,0 +1,165 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestNewRat(t *testing.T) {
+	tests := []struct {
+		name string
+		num  string
+		den  string
+		want string
+	}{
+		{"simple", "1", "3", "1/3"},
+		{"reduced", "6", "8", "3/4"},
+		{"integer", "10", "5", "2"},
+		{"negative numerator", "-2", "4", "-1/2"},
+		{"negative denominator", "2", "-4", "-1/2"},
+		{"both negative", "-2", "-4", "1/2"},
+		{"decimals", "1.5", "0.25", "6"},
+		{"decimal numerator", "0.1", "3", "1/30"},
+		{"zero", "0", "-7", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRat(Must(tt.num), Must(tt.den))
+			verify.NoError(t, err)
+			verify.Equal(t, r.String(), tt.want)
+		})
+	}
+
+	_, err := NewRat(Must("1"), Zero())
+	verify.IsError(t, err, ErrDivisionByZero)
+
+	_, err = NewRat(NaN(), Must("1"))
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = RatFromBCD(Inf(1))
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestParseRat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{"3/4", "3/4", false},
+		{"-3/4", "-3/4", false},
+		{"1 1/2", "3/2", false},
+		{"-1 1/2", "-3/2", false},
+		{"2 4/8", "5/2", false},
+		{"1.25", "5/4", false},
+		{"-7", "-7", false},
+		{"0.(3)", "1/3", false},
+		{"1.1(6)", "7/6", false},
+		{"0.(142857)", "1/7", false},
+		{"-0.58(3)", "-7/12", false},
+		{"1/0", "", true},
+		{"1/2/3", "", true},
+		{"a/2", "", true},
+		{"1.5/2", "", true},
+		{"0.(3", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ParseRat(tt.input)
+			if tt.err {
+				verify.IsError(t, err, ErrInvalidFormat)
+				return
+			}
+			verify.NoError(t, err)
+			verify.Equal(t, r.String(), tt.want)
+		})
+	}
+}
+
+func TestRatArithmetic(t *testing.T) {
+	third := MustRat(Must(1), Must(3))
+	sixth := MustRat(Must(1), Must(6))
+
+	verify.Equal(t, third.Add(sixth).String(), "1/2")
+	verify.Equal(t, third.Sub(sixth).String(), "1/6")
+	verify.Equal(t, sixth.Sub(third).String(), "-1/6")
+	verify.Equal(t, third.Mul(sixth).String(), "1/18")
+
+	q, err := third.Div(sixth)
+	verify.NoError(t, err)
+	verify.Equal(t, q.String(), "2")
+
+	zero, err := RatFromBCD(Zero())
+	verify.NoError(t, err)
+	_, err = third.Div(zero)
+	verify.IsError(t, err, ErrDivisionByZero)
+
+	inv, err := MustRat(Must(-2), Must(3)).Inv()
+	verify.NoError(t, err)
+	verify.Equal(t, inv.String(), "-3/2")
+
+	// Chained divisions stay exact
+	r := MustRat(Must(1), Must(1))
+	for range 3 {
+		r, _ = r.Div(MustRat(Must(3), Must(1)))
+	}
+	r = r.Mul(MustRat(Must(27), Must(1)))
+	verify.True(t, r.IsInteger())
+	verify.Equal(t, r.String(), "1")
+
+	verify.Equal(t, third.Cmp(sixth), 1)
+	verify.Equal(t, sixth.Neg().Cmp(sixth), -1)
+	verify.True(t, MustRat(Must(2), Must(6)).Equal(third))
+	verify.Equal(t, sixth.Neg().Abs().String(), "1/6")
+}
+
+func TestRatConversion(t *testing.T) {
+	third := MustRat(Must(1), Must(3))
+	twoThirds := MustRat(Must(-2), Must(3))
+
+	verify.Equal(t, third.ToBCD(4, RoundHalfEven).String(), "0.3333")
+	verify.Equal(t, third.ToBCD(4, RoundUp).String(), "0.3334")
+	verify.Equal(t, twoThirds.ToBCD(2, RoundHalfUp).String(), "-0.67")
+	verify.Equal(t, twoThirds.ToBCD(2, RoundCeiling).String(), "-0.66")
+
+	tests := []struct {
+		num         int
+		den         int
+		terminating bool
+		repeating   string
+		mixed       string
+	}{
+		{1, 3, false, "0.(3)", "1/3"},
+		{7, 12, false, "0.58(3)", "7/12"},
+		{1, 7, false, "0.(142857)", "1/7"},
+		{22, 7, false, "3.(142857)", "3 1/7"},
+		{-3, 2, true, "-1.5", "-1 1/2"},
+		{1, 8, true, "0.125", "1/8"},
+		{3, 80, true, "0.0375", "3/80"},
+		{5, 1, true, "5", "5"},
+	}
+
+	for _, tt := range tests {
+		r := MustRat(Must(tt.num), Must(tt.den))
+		verify.Equal(t, r.IsTerminating(), tt.terminating)
+		verify.Equal(t, r.RepeatingString(100), tt.repeating)
+		verify.Equal(t, r.MixedString(), tt.mixed)
+
+		// The repeating representation parses back
+		back, err := ParseRat(tt.repeating)
+		verify.NoError(t, err)
+		verify.True(t, back.Equal(r))
+	}
+
+	verify.Equal(t, MustRat(Must(1), Must(97)).RepeatingString(5), "0.01030...")
+}
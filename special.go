@@ -0,0 +1,160 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"strings"
+)
+
+// form distinguishes finite numbers from the IEEE 754-2008 special values.
+type form uint8
+
+const (
+	finite form = iota
+	infinite
+	quietNaN
+	signalingNaN
+)
+
+// NaN returns a quiet not-a-number. It is the result of invalid
+// operations like Inf - Inf and propagates through all arithmetic.
+func NaN() *BCD {
+	return &BCD{digits: []uint8{0}, form: quietNaN}
+}
+
+// SignalingNaN returns a signaling not-a-number. Arithmetic returning
+// a BCD turns it into a quiet NaN, operations returning an error fail
+// with ErrInvalidOperation.
+func SignalingNaN() *BCD {
+	return &BCD{digits: []uint8{0}, form: signalingNaN}
+}
+
+// Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Inf(sign int) *BCD {
+	return &BCD{digits: []uint8{0}, form: infinite, negative: sign < 0}
+}
+
+// NegZero returns a negative zero. It compares equal to zero but keeps
+// its sign through operations following the IEEE 754 rules.
+func NegZero() *BCD {
+	return &BCD{digits: []uint8{0}, negative: true}
+}
+
+// IsNaN returns true if the BCD is a quiet or signaling NaN.
+func (b *BCD) IsNaN() bool {
+	return b.form == quietNaN || b.form == signalingNaN
+}
+
+// IsSignalingNaN returns true if the BCD is a signaling NaN.
+func (b *BCD) IsSignalingNaN() bool {
+	return b.form == signalingNaN
+}
+
+// IsInf reports whether the BCD is an infinity, according to sign.
+// If sign > 0, IsInf reports whether b is positive infinity. If sign < 0,
+// IsInf reports whether b is negative infinity. If sign == 0, IsInf
+// reports whether b is either infinity.
+func (b *BCD) IsInf(sign int) bool {
+	if b.form != infinite {
+		return false
+	}
+	return sign == 0 || (sign > 0 && !b.negative) || (sign < 0 && b.negative)
+}
+
+// IsFinite returns true if the BCD is neither infinite nor a NaN.
+func (b *BCD) IsFinite() bool {
+	return b.form == finite
+}
+
+// Signbit reports whether the sign of the BCD is set. Different to
+// IsNegative it is true for negative zero and negative NaNs too.
+func (b *BCD) Signbit() bool {
+	return b.negative
+}
+
+// parseSpecial parses the special values NaN, sNaN, and Inf or Infinity
+// with optional sign, ignoring the case. The second result is false if
+// the string contains no special value.
+func parseSpecial(s string) (*BCD, bool) {
+	negative := false
+	unsigned := s
+	if strings.HasPrefix(unsigned, "-") {
+		negative = true
+		unsigned = unsigned[1:]
+	} else if strings.HasPrefix(unsigned, "+") {
+		unsigned = unsigned[1:]
+	}
+
+	var b *BCD
+	switch strings.ToLower(unsigned) {
+	case "nan":
+		b = NaN()
+	case "snan":
+		b = SignalingNaN()
+	case "inf", "infinity":
+		b = Inf(1)
+	default:
+		return nil, false
+	}
+	b.negative = negative
+	return b, true
+}
+
+// specialString returns the string representation of a special value.
+func (b *BCD) specialString() string {
+	var s string
+	switch b.form {
+	case infinite:
+		s = "Inf"
+	case quietNaN:
+		s = "NaN"
+	case signalingNaN:
+		s = "sNaN"
+	}
+	if b.negative {
+		return "-" + s
+	}
+	return s
+}
+
+// propagateNaN returns a quiet NaN if any operand is a NaN, otherwise nil.
+func propagateNaN(operands ...*BCD) *BCD {
+	for _, o := range operands {
+		if o.IsNaN() {
+			nan := NaN()
+			nan.negative = o.negative
+			return nan
+		}
+	}
+	return nil
+}
+
+// checkSignaling returns ErrInvalidOperation if any operand is a
+// signaling NaN.
+func checkSignaling(operands ...*BCD) error {
+	for _, o := range operands {
+		if o.IsSignalingNaN() {
+			return ErrInvalidOperation
+		}
+	}
+	return nil
+}
+
+// unsignedZero returns b, a negative zero as zero. It is used by the
+// types without signed zero like Amount, Rat, and Interval.
+func unsignedZero(b *BCD) *BCD {
+	if b.IsZero() {
+		return Zero()
+	}
+	return b
+}
+
+// isNegZero returns true if the BCD is a zero with the sign set.
+func (b *BCD) isNegZero() bool {
+	return b.negative && b.IsZero()
+}
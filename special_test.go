@@ -0,0 +1,309 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestSpecialParsing(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"NaN", "NaN"},
+		{"nan", "NaN"},
+		{"-NaN", "-NaN"},
+		{"sNaN", "sNaN"},
+		{"Inf", "Inf"},
+		{"+Inf", "Inf"},
+		{"-Inf", "-Inf"},
+		{"Infinity", "Inf"},
+		{"-infinity", "-Inf"},
+		{"-0", "-0"},
+		{"-0.000", "-0"},
+		{"+0", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			b, err := New(tt.input)
+			verify.NoError(t, err)
+			verify.Equal(t, b.String(), tt.want)
+
+			// Round trip
+			back, err := New(b.String())
+			verify.NoError(t, err)
+			verify.Equal(t, back.String(), tt.want)
+		})
+	}
+
+	_, err := New("Infinit")
+	verify.IsError(t, err, ErrInvalidFormat)
+}
+
+func TestSpecialPredicates(t *testing.T) {
+	verify.True(t, NaN().IsNaN())
+	verify.False(t, NaN().IsSignalingNaN())
+	verify.True(t, SignalingNaN().IsNaN())
+	verify.True(t, SignalingNaN().IsSignalingNaN())
+	verify.False(t, NaN().IsZero())
+	verify.False(t, NaN().IsFinite())
+
+	verify.True(t, Inf(1).IsInf(0))
+	verify.True(t, Inf(1).IsInf(1))
+	verify.False(t, Inf(1).IsInf(-1))
+	verify.True(t, Inf(-1).IsInf(-1))
+	verify.True(t, Inf(-1).IsNegative())
+	verify.True(t, Inf(1).IsPositive())
+	verify.False(t, Must("1").IsInf(0))
+	verify.True(t, Must("1").IsFinite())
+
+	verify.True(t, NegZero().IsZero())
+	verify.True(t, NegZero().Signbit())
+	verify.False(t, NegZero().IsNegative())
+	verify.False(t, Zero().Signbit())
+	verify.True(t, Must("-1").Signbit())
+	verify.True(t, Inf(-1).Signbit())
+}
+
+func TestSpecialArithmetic(t *testing.T) {
+	tests := []struct {
+		a    string
+		op   string
+		b    string
+		want string
+	}{
+		// NaN propagation
+		{"NaN", "+", "1", "NaN"},
+		{"1", "-", "NaN", "NaN"},
+		{"sNaN", "*", "2", "NaN"},
+		{"-NaN", "+", "Inf", "-NaN"},
+
+		// Infinities
+		{"Inf", "+", "1", "Inf"},
+		{"1", "-", "Inf", "-Inf"},
+		{"Inf", "+", "Inf", "Inf"},
+		{"Inf", "+", "-Inf", "NaN"},
+		{"Inf", "-", "Inf", "NaN"},
+		{"-Inf", "*", "2", "-Inf"},
+		{"-Inf", "*", "-2", "Inf"},
+		{"Inf", "*", "0", "NaN"},
+		{"Inf", "/", "2", "Inf"},
+		{"Inf", "/", "-Inf", "NaN"},
+		{"5", "/", "-Inf", "-0"},
+
+		// Signed zeros
+		{"-0", "+", "-0", "-0"},
+		{"-0", "+", "0", "0"},
+		{"-0", "-", "0", "-0"},
+		{"-0", "-", "-0", "0"},
+		{"-0", "*", "5", "-0"},
+		{"-0", "*", "-5", "0"},
+		{"-0", "/", "5", "-0"},
+
+		// Finite values keep their behavior
+		{"0", "*", "-5", "0"},
+		{"0", "/", "-5", "0"},
+		{"5", "-", "5", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+tt.op+tt.b, func(t *testing.T) {
+			a := Must(tt.a)
+			b := Must(tt.b)
+			var got *BCD
+			var err error
+			switch tt.op {
+			case "+":
+				got = a.Add(b)
+			case "-":
+				got = a.Sub(b)
+			case "*":
+				got = a.Mul(b)
+			case "/":
+				got, err = a.Div(b, 2, RoundHalfEven)
+				verify.NoError(t, err)
+			}
+			verify.Equal(t, got.String(), tt.want)
+		})
+	}
+}
+
+func TestSpecialUnary(t *testing.T) {
+	// Negation keeps zero unsigned and turns a negative zero into zero
+	verify.Equal(t, Zero().Neg().String(), "0")
+	verify.True(t, !Zero().Neg().Signbit())
+	verify.True(t, !NegZero().Neg().Signbit())
+	var z Var
+	verify.True(t, !z.Neg(Zero()).Signbit())
+	verify.True(t, !z.Neg(NegZero()).Signbit())
+	verify.Equal(t, z.Neg(Must("-1.5")).String(), "1.5")
+
+	// Types without signed zero stay unsigned
+	verify.True(t, !MustNewAmount("0", "EUR").Neg().Amount().Signbit())
+	verify.Equal(t, MustRat(Zero(), Must(1)).Neg().String(), "0")
+
+	// Signaling NaNs become quiet
+	for _, got := range []*BCD{
+		SignalingNaN().Round(2, RoundHalfEven),
+		SignalingNaN().Normalize(),
+		z.Round(SignalingNaN(), 2, RoundHalfEven).BCD.Copy(),
+	} {
+		verify.True(t, got.IsNaN())
+		verify.True(t, !got.IsSignalingNaN())
+	}
+	verify.Equal(t, Must("-sNaN").Round(0, RoundDown).String(), "-NaN")
+	verify.Equal(t, Inf(-1).Round(2, RoundHalfEven).String(), "-Inf")
+}
+
+func TestSpecialDivision(t *testing.T) {
+	// The error returning API is unchanged for finite values
+	_, err := Must("1").Div(Zero(), 2, RoundHalfEven)
+	verify.IsError(t, err, ErrDivisionByZero)
+	_, err = Must("1").Mod(Zero())
+	verify.IsError(t, err, ErrDivisionByZero)
+	_, err = Must("1").DivInt(Zero())
+	verify.IsError(t, err, ErrDivisionByZero)
+
+	// Signaling NaNs signal through errors
+	_, err = SignalingNaN().Div(Must("1"), 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Must("1").Mod(SignalingNaN())
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = SignalingNaN().Sqrt(2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	// Quo follows IEEE 754
+	verify.Equal(t, Must("1").Quo(Zero(), 2, RoundHalfEven).String(), "Inf")
+	verify.Equal(t, Must("-1").Quo(Zero(), 2, RoundHalfEven).String(), "-Inf")
+	verify.Equal(t, Must("1").Quo(NegZero(), 2, RoundHalfEven).String(), "-Inf")
+	verify.Equal(t, Zero().Quo(Zero(), 2, RoundHalfEven).String(), "NaN")
+	verify.Equal(t, SignalingNaN().Quo(Must("1"), 2, RoundHalfEven).String(), "NaN")
+	verify.Equal(t, Must("1").Quo(Must("3"), 2, RoundHalfEven).String(), "0.33")
+
+	// Integer division and modulo
+	q, err := Inf(1).DivInt(Must("2"))
+	verify.NoError(t, err)
+	verify.True(t, q.IsNaN())
+	q, err = Must("7").DivInt(Inf(1))
+	verify.NoError(t, err)
+	verify.Equal(t, q.String(), "0")
+	m, err := Must("7").Mod(Inf(-1))
+	verify.NoError(t, err)
+	verify.Equal(t, m.String(), "7")
+	m, err = Must("-6").Mod(Must("3"))
+	verify.NoError(t, err)
+	verify.Equal(t, m.String(), "0")
+
+	// Square roots
+	r, err := Inf(1).Sqrt(2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, r.String(), "Inf")
+	r, err = NegZero().Sqrt(2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, r.String(), "-0")
+	_, err = Inf(-1).Sqrt(2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestSpecialComparison(t *testing.T) {
+	verify.Equal(t, NegZero().Cmp(Zero()), 0)
+	verify.True(t, NegZero().Equal(Zero()))
+	verify.Equal(t, Inf(-1).Cmp(Must("-1000000")), -1)
+	verify.Equal(t, Inf(1).Cmp(Must("1000000")), 1)
+	verify.Equal(t, Inf(1).Cmp(Inf(1)), 0)
+	verify.Equal(t, Inf(-1).Cmp(Inf(1)), -1)
+
+	// Cmp sorts NaNs first, the predicates treat them as unordered
+	verify.Equal(t, NaN().Cmp(Inf(-1)), -1)
+	verify.Equal(t, Must("1").Cmp(NaN()), 1)
+	verify.Equal(t, NaN().Cmp(NaN()), 0)
+	verify.False(t, NaN().Equal(NaN()))
+	verify.False(t, NaN().LessThan(Must("1")))
+	verify.False(t, NaN().GreaterOrEqual(Must("1")))
+}
+
+func TestSpecialConversion(t *testing.T) {
+	verify.True(t, math.IsNaN(NaN().ToFloat64()))
+	verify.True(t, math.IsNaN(SignalingNaN().ToFloat64()))
+	verify.True(t, math.IsInf(Inf(1).ToFloat64(), 1))
+	verify.True(t, math.IsInf(Inf(-1).ToFloat64(), -1))
+	verify.True(t, math.Signbit(NegZero().ToFloat64()))
+
+	_, err := NaN().ToInt64()
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Inf(1).ToInt64()
+	verify.IsError(t, err, ErrOverflow)
+
+	// Float specials convert back and forth
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), math.Copysign(0, -1)} {
+		b, err := New(f)
+		verify.NoError(t, err)
+		back := b.ToFloat64()
+		verify.True(t, back == f || math.IsNaN(back) && math.IsNaN(f))
+		verify.Equal(t, math.Signbit(back), math.Signbit(f))
+	}
+	b, err := New(math.Inf(-1), WithScale(2))
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "-Inf")
+
+	// Only a real negative zero float keeps the sign
+	b, err = New(math.Copysign(0, -1))
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "-0")
+	b, err = New(-0.0001, WithScale(2))
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "0")
+
+	verify.Equal(t, Inf(1).Round(2, RoundHalfEven).String(), "Inf")
+	verify.Equal(t, NaN().Normalize().String(), "NaN")
+	verify.Equal(t, Inf(-1).Abs().String(), "Inf")
+	verify.Equal(t, Inf(-1).Neg().String(), "Inf")
+}
+
+func TestSpecialAmount(t *testing.T) {
+	_, err := NewAmount(NaN(), "USD")
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = NewAmount("Inf", "EUR")
+	verify.IsError(t, err, ErrInvalidAmount)
+
+	a, err := NewAmount("-0", "EUR")
+	verify.NoError(t, err)
+	verify.Equal(t, a.Amount().String(), "0")
+	verify.Equal(t, a.String(), "€0.00")
+
+	// Operations keep amounts finite and without signed zero
+	ten := MustNewAmount("10", "EUR")
+	_, err = ten.MulChecked(NaN())
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = ten.MulChecked(Inf(1))
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = ten.Mul(Inf(-1)).MarshalText()
+	verify.IsError(t, err, ErrInvalidAmount)
+	p, err := ten.MulChecked(Must("1.5"))
+	verify.NoError(t, err)
+	verify.Equal(t, p.String(), "€15.00")
+	verify.True(t, !ten.Mul(NegZero()).Amount().Signbit())
+	verify.True(t, !MustNewAmount("0", "EUR").MulInt64(-3).Amount().Signbit())
+
+	_, err = ten.MulFloat64(math.NaN())
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = ten.Div(Inf(-1))
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = ten.Div(NaN())
+	verify.IsError(t, err, ErrInvalidAmount)
+	_, err = ten.DivFloat64(math.Inf(1))
+	verify.IsError(t, err, ErrInvalidAmount)
+	q, err := MustNewAmount("0", "EUR").DivInt64(-4)
+	verify.NoError(t, err)
+	verify.True(t, !q.Amount().Signbit())
+}
@@ -0,0 +1,247 @@
+// Tideland Go BCD - Statement
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package statement
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"tideland.dev/go/bcd/iso20022"
+)
+
+// Balance types of camt.053 used as opening and closing balances.
+const (
+	camtOpeningBooked  = "OPBD"
+	camtPreviousClosed = "PRCD"
+	camtClosingBooked  = "CLBD"
+	camtBooked         = "BOOK"
+	camtDebit          = "DBIT"
+	camtCredit         = "CRDT"
+)
+
+// camtAccount is the account of a camt.053 statement.
+type camtAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+// camtDate is a date or date and time of camt.053.
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtBalance is a balance of a camt.053 statement.
+type camtBalance struct {
+	Type   string                            `xml:"Tp>CdOrPrtry>Cd"`
+	Amount *iso20022.ActiveCurrencyAndAmount `xml:"Amt"`
+	Mark   string                            `xml:"CdtDbtInd"`
+	Date   camtDate                          `xml:"Dt"`
+}
+
+// camtStatus is the status of an entry, a code since camt.053.001.08
+// and plain text before.
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+// camtEntry is an entry of a camt.053 statement.
+type camtEntry struct {
+	Reference     string                            `xml:"NtryRef"`
+	Amount        *iso20022.ActiveCurrencyAndAmount `xml:"Amt"`
+	Mark          string                            `xml:"CdtDbtInd"`
+	Reversal      bool                              `xml:"RvslInd"`
+	Status        camtStatus                        `xml:"Sts"`
+	BookingDate   camtDate                          `xml:"BookgDt"`
+	ValueDate     camtDate                          `xml:"ValDt"`
+	BankReference string                            `xml:"AcctSvcrRef"`
+	EndToEndID    string                            `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Details       string                            `xml:"AddtlNtryInf"`
+}
+
+// ParseCAMT053 parses the statements of a camt.053 document. Entries
+// without the status BOOK are pending. The reference of an entry is the
+// entry reference, or the end-to-end identification of its transaction
+// if missing.
+func ParseCAMT053(r io.Reader) ([]*Statement, error) {
+	d := xml.NewDecoder(r)
+	var statements []*Statement
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Stmt" {
+			continue
+		}
+		s, err := parseCAMTStatement(d)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, s)
+	}
+	return statements, nil
+}
+
+// parseCAMTStatement parses the children of a Stmt element.
+func parseCAMTStatement(d *xml.Decoder) (*Statement, error) {
+	s := &Statement{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		if _, ok := token.(xml.EndElement); ok {
+			break
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		line, _ := d.InputPos()
+		switch start.Name.Local {
+		case "Id":
+			err = d.DecodeElement(&s.ID, &start)
+		case "Acct":
+			var acct camtAccount
+			err = d.DecodeElement(&acct, &start)
+			s.Account = acct.IBAN + acct.Other
+		case "Bal":
+			err = parseCAMTBalance(d, start, line, s)
+		case "Ntry":
+			err = parseCAMTEntry(d, start, line, s)
+		default:
+			err = d.Skip()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("statement %q line %d: %w", s.ID, line, err)
+		}
+	}
+	return s, nil
+}
+
+// parseCAMTBalance parses a balance and sets it as opening or closing
+// balance of the statement. Other balance types are ignored.
+func parseCAMTBalance(d *xml.Decoder, start xml.StartElement, line int, s *Statement) error {
+	var bal camtBalance
+	if err := d.DecodeElement(&bal, &start); err != nil {
+		return err
+	}
+	if bal.Amount == nil {
+		return fmt.Errorf("%w: balance without amount", ErrFormat)
+	}
+	mark, err := parseCAMTMark(bal.Mark)
+	if err != nil {
+		return err
+	}
+	date, err := parseCAMTDate(bal.Date)
+	if err != nil {
+		return err
+	}
+	amount := bal.Amount.Amount()
+	if mark == Debit {
+		amount = amount.Neg()
+	}
+	balance := Balance{Date: date, Amount: amount, Line: line}
+	switch bal.Type {
+	case camtOpeningBooked:
+		s.Opening = balance
+	case camtPreviousClosed:
+		if s.Opening.Amount == nil {
+			s.Opening = balance
+		}
+	case camtClosingBooked:
+		s.Closing = balance
+	}
+	return nil
+}
+
+// parseCAMTEntry parses an entry and appends it to the statement.
+func parseCAMTEntry(d *xml.Decoder, start xml.StartElement, line int, s *Statement) error {
+	var ntry camtEntry
+	if err := d.DecodeElement(&ntry, &start); err != nil {
+		return err
+	}
+	if ntry.Amount == nil {
+		return fmt.Errorf("%w: entry without amount", ErrFormat)
+	}
+	mark, err := parseCAMTMark(ntry.Mark)
+	if err != nil {
+		return err
+	}
+	bookingDate, err := parseCAMTDate(ntry.BookingDate)
+	if err != nil {
+		return err
+	}
+	valueDate, err := parseCAMTDate(ntry.ValueDate)
+	if err != nil {
+		return err
+	}
+	status := strings.TrimSpace(ntry.Status.Text)
+	if ntry.Status.Code != "" {
+		status = ntry.Status.Code
+	}
+	reference := ntry.Reference
+	if reference == "" {
+		reference = ntry.EndToEndID
+	}
+	s.Entries = append(s.Entries, Entry{
+		BookingDate:   bookingDate,
+		ValueDate:     valueDate,
+		Amount:        ntry.Amount.Amount(),
+		Mark:          mark,
+		Reversal:      ntry.Reversal,
+		Pending:       status != camtBooked,
+		Reference:     reference,
+		BankReference: ntry.BankReference,
+		Details:       ntry.Details,
+		Line:          line,
+	})
+	return nil
+}
+
+// parseCAMTMark parses a credit or debit indicator.
+func parseCAMTMark(s string) (CreditDebit, error) {
+	switch s {
+	case camtCredit:
+		return Credit, nil
+	case camtDebit:
+		return Debit, nil
+	}
+	return Credit, fmt.Errorf("%w: credit debit indicator %q", ErrFormat, s)
+}
+
+// parseCAMTDate parses a date or the date of a date and time. Missing
+// dates are returned as zero time.
+func parseCAMTDate(d camtDate) (time.Time, error) {
+	switch {
+	case d.Date != "":
+		date, err := time.Parse(time.DateOnly, d.Date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: date %q", ErrFormat, d.Date)
+		}
+		return date, nil
+	case d.DateTime != "":
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+			if date, err := time.Parse(layout, d.DateTime); err == nil {
+				return date, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%w: date and time %q", ErrFormat, d.DateTime)
+	}
+	return time.Time{}, nil
+}
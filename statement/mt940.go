@@ -0,0 +1,215 @@
+// Tideland Go BCD - Statement
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"tideland.dev/go/bcd/swift"
+)
+
+// mt940Field is a field of an MT940 message with its tag, its value
+// including continuation lines, and the line it starts.
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// ParseMT940 parses the MT940 messages of the text, each one as statement.
+// Messages end with a line "-" or start with a new field 20. Lines of the
+// SWIFT block headers are ignored. The balances are read from the fields
+// 60F or 60M and 62F or 62M, the entries from 61 and their details from
+// the following 86.
+func ParseMT940(r io.Reader) ([]*Statement, error) {
+	var statements []*Statement
+	var fields []mt940Field
+	flush := func() error {
+		if len(fields) == 0 {
+			return nil
+		}
+		s, err := parseMT940Message(fields)
+		if err != nil {
+			return err
+		}
+		statements = append(statements, s)
+		fields = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case text == "-" || text == "-}":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(text, "{"):
+			// Block headers, the text block follows in the next lines
+		case strings.HasPrefix(text, ":"):
+			tag, value, ok := strings.Cut(text[1:], ":")
+			if !ok {
+				return nil, fmt.Errorf("%w: line %d: field %q without tag", ErrFormat, line, text)
+			}
+			if tag == "20" {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+			fields = append(fields, mt940Field{tag: tag, value: value, line: line})
+		case len(fields) > 0:
+			fields[len(fields)-1].value += "\n" + text
+		case strings.TrimSpace(text) != "":
+			return nil, fmt.Errorf("%w: line %d: text outside of a field", ErrFormat, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// parseMT940Message creates a statement from the fields of a message.
+func parseMT940Message(fields []mt940Field) (*Statement, error) {
+	s := &Statement{}
+	for _, field := range fields {
+		var err error
+		switch field.tag {
+		case "20":
+			s.ID = field.value
+		case "25":
+			s.Account = field.value
+		case "60F", "60M":
+			s.Opening, err = parseMT940Balance(field)
+		case "62F", "62M":
+			s.Closing, err = parseMT940Balance(field)
+		case "61":
+			var e Entry
+			e, err = parseMT940Entry(field, s.Currency())
+			s.Entries = append(s.Entries, e)
+		case "86":
+			if n := len(s.Entries); n > 0 {
+				s.Entries[n-1].Details = field.value
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("statement %q line %d field %s: %w", s.ID, field.line, field.tag, err)
+		}
+	}
+	return s, nil
+}
+
+// parseMT940Balance parses a balance field.
+func parseMT940Balance(field mt940Field) (Balance, error) {
+	b, err := swift.ParseBalance(field.value)
+	if err != nil {
+		return Balance{}, err
+	}
+	return Balance{Date: b.Date, Amount: b.Amount, Line: field.line}, nil
+}
+
+// parseMT940Entry parses a statement line of field 61 in the format
+// 6!n[4!n]2a[1!a]15d1!a3!c16x[//16x][34x] with value date, booking date,
+// mark, funds code, amount, transaction type, and references.
+func parseMT940Entry(field mt940Field, currency string) (Entry, error) {
+	if currency == "" {
+		return Entry{}, fmt.Errorf("%w: entry before opening balance", ErrMissingBalance)
+	}
+	s, supplementary, _ := strings.Cut(field.value, "\n")
+	if len(s) < 6 {
+		return Entry{}, fmt.Errorf("%w: statement line %q too short", ErrFormat, s)
+	}
+	valueDate, err := swift.ParseDate(s[:6])
+	if err != nil {
+		return Entry{}, err
+	}
+	s = s[6:]
+	e := Entry{ValueDate: valueDate, BookingDate: valueDate, Details: supplementary, Line: field.line}
+
+	// Optional booking date MMDD in the year closest to the value date
+	if len(s) >= 4 && swift.IsDigits(s[:4]) {
+		month, _ := strconv.Atoi(s[:2])
+		day, _ := strconv.Atoi(s[2:4])
+		if e.BookingDate, err = closestDate(time.Month(month), day, valueDate); err != nil {
+			return Entry{}, err
+		}
+		s = s[4:]
+	}
+
+	// Mark with optional reversal and funds code
+	switch {
+	case strings.HasPrefix(s, "RC"):
+		e.Mark, e.Reversal, s = Debit, true, s[2:]
+	case strings.HasPrefix(s, "RD"):
+		e.Mark, e.Reversal, s = Credit, true, s[2:]
+	case strings.HasPrefix(s, "C"):
+		e.Mark, s = Credit, s[1:]
+	case strings.HasPrefix(s, "D"):
+		e.Mark, s = Debit, s[1:]
+	default:
+		return Entry{}, fmt.Errorf("%w: statement line without debit or credit mark", ErrFormat)
+	}
+	if s != "" && s[0] >= 'A' && s[0] <= 'Z' {
+		s = s[1:]
+	}
+
+	// Amount up to the transaction type
+	n := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != ',' })
+	if n < 0 {
+		return Entry{}, fmt.Errorf("%w: statement line without transaction type", ErrFormat)
+	}
+	if e.Amount, err = swift.ParseAmount(s[:n], currency); err != nil {
+		return Entry{}, err
+	}
+	s = s[n:]
+	if len(s) < 4 {
+		return Entry{}, fmt.Errorf("%w: statement line without transaction type", ErrFormat)
+	}
+	e.Reference, e.BankReference, _ = strings.Cut(s[4:], "//")
+	return e, nil
+}
+
+// closestDate returns the date of month and day in the year which brings
+// it closest to the reference, e.g. a booking on January 2nd of a value
+// date on December 31st is in the next year.
+func closestDate(month time.Month, day int, reference time.Time) (time.Time, error) {
+	var best time.Time
+	for _, year := range []int{reference.Year() - 1, reference.Year(), reference.Year() + 1} {
+		candidate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if candidate.Month() != month || candidate.Day() != day {
+			// Normalized, e.g. February 29th of a non-leap year
+			continue
+		}
+		if best.IsZero() || absDuration(candidate.Sub(reference)) < absDuration(best.Sub(reference)) {
+			best = candidate
+		}
+	}
+	if best.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: booking date %02d%02d", swift.ErrDate, month, day)
+	}
+	return best, nil
+}
+
+// absDuration returns the absolute value of the duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
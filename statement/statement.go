@@ -0,0 +1,169 @@
+// Tideland Go BCD - Statement
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Package statement imports bank statements in the formats camt.053 and
+// MT940 into typed entries with Amount values. Verify checks exactly that
+// the opening balance plus the credits minus the debits equals the
+// closing balance, and that consecutive statements continue each other.
+// Errors name the line and entry where a statement does not reconcile.
+package statement
+
+import (
+	"fmt"
+	"time"
+
+	"tideland.dev/go/bcd"
+)
+
+// Statement errors.
+var (
+	ErrFormat         = fmt.Errorf("invalid statement format")
+	ErrMissingBalance = fmt.Errorf("missing statement balance")
+	ErrUnbalanced     = fmt.Errorf("statement does not reconcile")
+)
+
+// CreditDebit marks an entry as credit or debit.
+type CreditDebit int
+
+// Credit and debit marks.
+const (
+	Credit CreditDebit = iota
+	Debit
+)
+
+// String returns "CRDT" or "DBIT" like the camt.053 indicator.
+func (cd CreditDebit) String() string {
+	if cd == Debit {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+// Balance is an opening or closing balance of a statement. Debit
+// balances have a negative amount.
+type Balance struct {
+	Date   time.Time
+	Amount *bcd.Amount
+	Line   int
+}
+
+// Entry is a booking of a statement. The amount is always positive, the
+// mark defines its direction.
+type Entry struct {
+	BookingDate   time.Time
+	ValueDate     time.Time
+	Amount        *bcd.Amount
+	Mark          CreditDebit
+	Reversal      bool
+	Pending       bool
+	Reference     string
+	BankReference string
+	Details       string
+	Line          int
+}
+
+// Signed returns the amount of the entry, negative for debits.
+func (e Entry) Signed() *bcd.Amount {
+	if e.Mark == Debit {
+		return e.Amount.Neg()
+	}
+	return e.Amount
+}
+
+// Statement is an account statement with its balances and entries.
+type Statement struct {
+	ID      string
+	Account string
+	Opening Balance
+	Closing Balance
+	Entries []Entry
+}
+
+// Currency returns the currency code of the opening balance.
+func (s *Statement) Currency() string {
+	if s.Opening.Amount == nil {
+		return ""
+	}
+	return s.Opening.Amount.Code()
+}
+
+// Verify checks that the opening balance plus the booked credits minus
+// the booked debits exactly equals the closing balance. Pending entries
+// are not part of the balances. An entry in another currency returns
+// bcd.ErrCurrencyMismatch, a difference a BalanceError.
+func (s *Statement) Verify() error {
+	if s.Opening.Amount == nil || s.Closing.Amount == nil {
+		return fmt.Errorf("%w: statement %q", ErrMissingBalance, s.ID)
+	}
+	balance := s.Opening.Amount
+	booked := 0
+	for i, e := range s.Entries {
+		if e.Pending {
+			continue
+		}
+		next, err := balance.Add(e.Signed())
+		if err != nil {
+			return fmt.Errorf("statement %q entry %d in line %d: %w", s.ID, i+1, e.Line, err)
+		}
+		balance = next
+		booked++
+	}
+	if !balance.Equal(s.Closing.Amount) {
+		return &BalanceError{
+			Statement: s.ID,
+			Line:      s.Closing.Line,
+			Entry:     booked,
+			Expected:  balance,
+			Actual:    s.Closing.Amount,
+		}
+	}
+	return nil
+}
+
+// Verify checks the statements in order. Besides each statement, the
+// opening balance of every statement has to equal the closing balance
+// of its predecessor of the same account.
+func Verify(statements []*Statement) error {
+	for i, s := range statements {
+		if i > 0 && statements[i-1].Account == s.Account && s.Opening.Amount != nil {
+			prev := statements[i-1]
+			if !prev.Closing.Amount.Equal(s.Opening.Amount) {
+				return &BalanceError{
+					Statement: s.ID,
+					Line:      s.Opening.Line,
+					Expected:  prev.Closing.Amount,
+					Actual:    s.Opening.Amount,
+				}
+			}
+		}
+		if err := s.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BalanceError reports the first balance of a statement which does not
+// match the balance calculated up to it.
+type BalanceError struct {
+	Statement string
+	Line      int
+	Entry     int // Number of booked entries before the balance
+	Expected  *bcd.Amount
+	Actual    *bcd.Amount
+}
+
+// Error implements the error interface.
+func (e *BalanceError) Error() string {
+	return fmt.Sprintf("%v: statement %q line %d after %d booked entries: expected %s, got %s",
+		ErrUnbalanced, e.Statement, e.Line, e.Entry, e.Expected.Format(false, true), e.Actual.Format(false, true))
+}
+
+// Unwrap returns ErrUnbalanced.
+func (e *BalanceError) Unwrap() error {
+	return ErrUnbalanced
+}
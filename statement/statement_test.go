@@ -0,0 +1,279 @@
+// Tideland Go BCD - Statement - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package statement
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+	"tideland.dev/go/bcd/iso20022"
+	"tideland.dev/go/bcd/swift"
+)
+
+// camt053 is a statement with two booked and one pending entry.
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr>
+      <MsgId>MSG-20250915</MsgId>
+      <CreDtTm>2025-09-15T18:00:00+02:00</CreDtTm>
+    </GrpHdr>
+    <Stmt>
+      <Id>STMT-2025-181</Id>
+      <Acct>
+        <Id>
+          <IBAN>DE89370400440532013000</IBAN>
+        </Id>
+        <Ccy>EUR</Ccy>
+      </Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-09-14</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1170.55</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-09-15</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>E1</NtryRef>
+        <Amt Ccy="EUR">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2025-09-15</Dt></BookgDt>
+        <ValDt><Dt>2025-09-15</Dt></ValDt>
+        <AcctSvcrRef>BANK-0001</AcctSvcrRef>
+        <AddtlNtryInf>Invoice 4711</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">79.45</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2025-09-15T10:30:00+02:00</DtTm></BookgDt>
+        <ValDt><Dt>2025-09-16</Dt></ValDt>
+        <AcctSvcrRef>BANK-0002</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><EndToEndId>E2E-42</EndToEndId></Refs>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">500.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <ValDt><Dt>2025-09-17</Dt></ValDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+// mt940 are two messages of a statement, the second one with a reversal
+// at the end of the year.
+const mt940 = `{1:F01BANKDEFFAXXX0000000000}{2:O9401200250915BANKDEFFAXXX00000000002509151200N}{4:
+:20:STMT-181
+:25:37040044/0532013000
+:28C:181/1
+:60F:C250914EUR1000,00
+:61:2509150915CR250,00NTRFE1//BANK-0001
+:86:Invoice 4711
+:61:2509160915DR79,45NDDTE2E-42//BANK-0002
+SEPA direct debit
+:62M:C250915EUR1170,55
+-}
+{1:F01BANKDEFFAXXX0000000000}{2:O9401200250915BANKDEFFAXXX00000000002509151200N}{4:
+:20:STMT-182
+:25:37040044/0532013000
+:28C:181/2
+:60M:C250915EUR1170,55
+:61:2512311231RC1170,55NTRFREVERSAL
+:61:2512310102D100,00NCHGFEES
+:62F:D260102EUR100,00
+-}
+`
+
+// date creates a date in UTC.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseCAMT053(t *testing.T) {
+	statements, err := ParseCAMT053(strings.NewReader(camt053))
+	verify.NoError(t, err)
+	verify.Equal(t, len(statements), 1)
+
+	s := statements[0]
+	verify.Equal(t, s.ID, "STMT-2025-181")
+	verify.Equal(t, s.Account, "DE89370400440532013000")
+	verify.Equal(t, s.Currency(), "EUR")
+	verify.Equal(t, s.Opening.Amount.Format(false, true), "1000.00 EUR")
+	verify.Equal(t, s.Opening.Date, date(2025, time.September, 14))
+	verify.Equal(t, s.Closing.Amount.Format(false, true), "1170.55 EUR")
+	verify.Equal(t, len(s.Entries), 3)
+
+	e := s.Entries[0]
+	verify.Equal(t, e.Mark, Credit)
+	verify.Equal(t, e.Amount.Format(false, true), "250.00 EUR")
+	verify.Equal(t, e.Reference, "E1")
+	verify.Equal(t, e.BankReference, "BANK-0001")
+	verify.Equal(t, e.Details, "Invoice 4711")
+	verify.Equal(t, e.BookingDate, date(2025, time.September, 15))
+	verify.Equal(t, e.Line, 28)
+
+	e = s.Entries[1]
+	verify.Equal(t, e.Mark, Debit)
+	verify.Equal(t, e.Signed().Format(false, true), "-79.45 EUR")
+	verify.Equal(t, e.Reference, "E2E-42")
+	verify.Equal(t, e.BookingDate.Format(time.DateOnly), "2025-09-15")
+	verify.Equal(t, e.ValueDate, date(2025, time.September, 16))
+	verify.True(t, !e.Pending)
+	verify.True(t, s.Entries[2].Pending)
+
+	verify.NoError(t, s.Verify())
+	verify.NoError(t, Verify(statements))
+}
+
+func TestParseCAMT053Errors(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		err  error
+	}{
+		{`<Amt Ccy="EUR">79.45</Amt>`, `<Amt Ccy="EUR">-79.45</Amt>`, iso20022.ErrPattern},
+		{`<Amt Ccy="EUR">79.45</Amt>`, `<Amt Ccy="EUR">79.455</Amt>`, iso20022.ErrCurrencyAmount},
+		{`<CdtDbtInd>DBIT</CdtDbtInd>`, `<CdtDbtInd>DEBIT</CdtDbtInd>`, ErrFormat},
+		{`<Dt>2025-09-16</Dt>`, `<Dt>16.09.2025</Dt>`, ErrFormat},
+		{`</Document>`, ``, ErrFormat},
+	}
+	for _, test := range tests {
+		_, err := ParseCAMT053(strings.NewReader(strings.Replace(camt053, test.from, test.to, 1)))
+		verify.IsError(t, err, test.err)
+	}
+
+	// Syntax errors keep their cause
+	_, err := ParseCAMT053(strings.NewReader(strings.Replace(camt053, "</Document>", "", 1)))
+	verify.IsError(t, err, ErrFormat)
+	var syntaxErr *xml.SyntaxError
+	verify.True(t, errors.As(err, &syntaxErr))
+}
+
+func TestParseMT940(t *testing.T) {
+	statements, err := ParseMT940(strings.NewReader(mt940))
+	verify.NoError(t, err)
+	verify.Equal(t, len(statements), 2)
+
+	s := statements[0]
+	verify.Equal(t, s.ID, "STMT-181")
+	verify.Equal(t, s.Account, "37040044/0532013000")
+	verify.Equal(t, s.Opening.Amount.Format(false, true), "1000.00 EUR")
+	verify.Equal(t, s.Opening.Line, 5)
+	verify.Equal(t, s.Closing.Line, 10)
+	verify.Equal(t, len(s.Entries), 2)
+
+	e := s.Entries[0]
+	verify.Equal(t, e.Mark, Credit)
+	verify.Equal(t, e.Amount.Format(false, true), "250.00 EUR")
+	verify.Equal(t, e.ValueDate, date(2025, time.September, 15))
+	verify.Equal(t, e.Reference, "E1")
+	verify.Equal(t, e.BankReference, "BANK-0001")
+	verify.Equal(t, e.Details, "Invoice 4711")
+	verify.Equal(t, e.Line, 6)
+
+	e = s.Entries[1]
+	verify.Equal(t, e.Mark, Debit)
+	verify.Equal(t, e.ValueDate, date(2025, time.September, 16))
+	verify.Equal(t, e.BookingDate, date(2025, time.September, 15))
+	verify.Equal(t, e.Reference, "E2E-42")
+	verify.Equal(t, e.Details, "SEPA direct debit")
+
+	s = statements[1]
+	verify.Equal(t, s.Closing.Amount.Format(false, true), "-100.00 EUR")
+	e = s.Entries[0]
+	verify.True(t, e.Reversal)
+	verify.Equal(t, e.Mark, Debit)
+	e = s.Entries[1]
+	verify.Equal(t, e.BookingDate, date(2026, time.January, 2))
+	verify.Equal(t, e.Reference, "FEES")
+
+	verify.NoError(t, Verify(statements))
+}
+
+func TestParseMT940Errors(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		err  error
+	}{
+		{":61:2509150915CR250,00", ":61:2509150915XR250,00", ErrFormat},
+		{":61:2509150915CR250,00", ":61:2509150915CR250.00", swift.ErrFormat},
+		{":61:2509150915CR250,00", ":61:2509150230CR250,00", swift.ErrDate},
+		{":60F:C250914EUR1000,00", ":60F:C250914EUR1000", swift.ErrFormat},
+		{":60F:C250914EUR1000,00\n", "", ErrMissingBalance},
+		{":20:STMT-181", "STMT-181", ErrFormat},
+	}
+	for _, test := range tests {
+		_, err := ParseMT940(strings.NewReader(strings.Replace(mt940, test.from, test.to, 1)))
+		verify.IsError(t, err, test.err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Closing balance of the first message is off by one cent.
+	statements, err := ParseMT940(strings.NewReader(strings.Replace(mt940, ":62M:C250915EUR1170,55", ":62M:C250915EUR1170,56", 1)))
+	verify.NoError(t, err)
+	err = Verify(statements)
+	verify.IsError(t, err, ErrUnbalanced)
+	var be *BalanceError
+	verify.True(t, errors.As(err, &be))
+	verify.Equal(t, be.Statement, "STMT-181")
+	verify.Equal(t, be.Line, 10)
+	verify.Equal(t, be.Entry, 2)
+	verify.Equal(t, be.Expected.Format(false, true), "1170.55 EUR")
+	verify.Equal(t, be.Actual.Format(false, true), "1170.56 EUR")
+
+	// Second message does not continue the first one.
+	statements, err = ParseMT940(strings.NewReader(strings.Replace(mt940, ":60M:C250915EUR1170,55", ":60M:C250915EUR1270,55", 1)))
+	verify.NoError(t, err)
+	err = Verify(statements)
+	verify.True(t, errors.As(err, &be))
+	verify.Equal(t, be.Statement, "STMT-182")
+	verify.Equal(t, be.Line, 16)
+	verify.Equal(t, be.Entry, 0)
+
+	// Pending entries are not counted
+	statements, err = ParseCAMT053(strings.NewReader(strings.Replace(camt053, "1170.55", "670.55", 1)))
+	verify.NoError(t, err)
+	err = statements[0].Verify()
+	verify.True(t, errors.As(err, &be))
+	verify.Equal(t, be.Entry, 2)
+	verify.True(t, strings.Contains(err.Error(), "after 2 booked entries"))
+
+	// Entries in another currency
+	statements, err = ParseMT940(strings.NewReader(mt940))
+	verify.NoError(t, err)
+	s := statements[0]
+	usd, err := bcd.NewAmount("1", "USD")
+	verify.NoError(t, err)
+	s.Entries = append(s.Entries, Entry{Amount: usd, Line: 42})
+	err = s.Verify()
+	verify.IsError(t, err, bcd.ErrCurrencyMismatch)
+	verify.True(t, strings.Contains(err.Error(), "entry 3 in line 42"))
+
+	err = (&Statement{ID: "empty"}).Verify()
+	verify.IsError(t, err, ErrMissingBalance)
+}
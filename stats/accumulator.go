@@ -0,0 +1,163 @@
+// Tideland Go BCD - Statistics
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package stats
+
+import (
+	"tideland.dev/go/bcd"
+)
+
+// Accumulator collects streaming values and provides exact aggregate
+// statistics at any time. Only the count, the sum, the sum of squares
+// and the extremes are kept, so the memory usage does not grow with
+// the number of values. An Accumulator is not safe for concurrent use.
+type Accumulator struct {
+	count      int64
+	sum        *bcd.BCD
+	sumSquares *bcd.BCD
+	min        *bcd.BCD
+	max        *bcd.BCD
+}
+
+// NewAccumulator creates an empty accumulator.
+func NewAccumulator() *Accumulator {
+	acc := &Accumulator{}
+	acc.Reset()
+	return acc
+}
+
+// Reset removes all collected values.
+func (acc *Accumulator) Reset() {
+	acc.count = 0
+	acc.sum = bcd.Zero()
+	acc.sumSquares = bcd.Zero()
+	acc.min = nil
+	acc.max = nil
+}
+
+// Add adds the values to the accumulator.
+func (acc *Accumulator) Add(values ...*bcd.BCD) {
+	for _, v := range values {
+		acc.count++
+		acc.sum = acc.sum.Add(v)
+		acc.sumSquares = acc.sumSquares.Add(v.Mul(v))
+		if acc.min == nil || v.LessThan(acc.min) {
+			acc.min = v.Copy()
+		}
+		if acc.max == nil || v.GreaterThan(acc.max) {
+			acc.max = v.Copy()
+		}
+	}
+}
+
+// Merge adds all values collected by the other accumulator, e.g. when
+// partitions of a data set have been accumulated in parallel.
+func (acc *Accumulator) Merge(other *Accumulator) {
+	if other.count == 0 {
+		return
+	}
+	acc.count += other.count
+	acc.sum = acc.sum.Add(other.sum)
+	acc.sumSquares = acc.sumSquares.Add(other.sumSquares)
+	if acc.min == nil || other.min.LessThan(acc.min) {
+		acc.min = other.min.Copy()
+	}
+	if acc.max == nil || other.max.GreaterThan(acc.max) {
+		acc.max = other.max.Copy()
+	}
+}
+
+// Count returns the number of collected values.
+func (acc *Accumulator) Count() int64 {
+	return acc.count
+}
+
+// Sum returns the exact sum of the collected values.
+func (acc *Accumulator) Sum() *bcd.BCD {
+	return acc.sum.Copy()
+}
+
+// Min returns the smallest collected value.
+func (acc *Accumulator) Min() (*bcd.BCD, error) {
+	if acc.count == 0 {
+		return nil, ErrNoValues
+	}
+	return acc.min.Copy(), nil
+}
+
+// Max returns the largest collected value.
+func (acc *Accumulator) Max() (*bcd.BCD, error) {
+	if acc.count == 0 {
+		return nil, ErrNoValues
+	}
+	return acc.max.Copy(), nil
+}
+
+// Mean returns the arithmetic mean of the collected values.
+func (acc *Accumulator) Mean(scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	if acc.count == 0 {
+		return nil, ErrNoValues
+	}
+	return acc.sum.Div(bcd.Must(acc.count), scale, mode)
+}
+
+// Variance returns the population variance of the collected values.
+func (acc *Accumulator) Variance(scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	num, den, err := acc.variance(false)
+	if err != nil {
+		return nil, err
+	}
+	return num.Div(den, scale, mode)
+}
+
+// SampleVariance returns the sample variance of the collected values.
+func (acc *Accumulator) SampleVariance(scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	num, den, err := acc.variance(true)
+	if err != nil {
+		return nil, err
+	}
+	return num.Div(den, scale, mode)
+}
+
+// StdDev returns the population standard deviation of the collected values.
+func (acc *Accumulator) StdDev(scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	num, den, err := acc.variance(false)
+	if err != nil {
+		return nil, err
+	}
+	return sqrtQuotient(num, den, scale, mode)
+}
+
+// SampleStdDev returns the sample standard deviation of the collected values.
+func (acc *Accumulator) SampleStdDev(scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	num, den, err := acc.variance(true)
+	if err != nil {
+		return nil, err
+	}
+	return sqrtQuotient(num, den, scale, mode)
+}
+
+// variance returns numerator and denominator of the exact variance. The
+// numerator is n*sum(x^2) - sum(x)^2, the denominator n^2 for the
+// population and n*(n-1) for the sample variance.
+func (acc *Accumulator) variance(sample bool) (*bcd.BCD, *bcd.BCD, error) {
+	if acc.count == 0 {
+		return nil, nil, ErrNoValues
+	}
+	if sample && acc.count < 2 {
+		return nil, nil, ErrInsufficientValues
+	}
+
+	n := bcd.Must(acc.count)
+	num := n.Mul(acc.sumSquares).Sub(acc.sum.Mul(acc.sum))
+	den := n.Mul(n)
+	if sample {
+		den = n.Mul(n.Sub(one))
+	}
+
+	return num, den, nil
+}
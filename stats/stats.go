@@ -0,0 +1,208 @@
+// Tideland Go BCD - Statistics
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Package stats provides exact aggregate statistics over slices of BCD
+// values. All intermediate results are computed without rounding, only
+// the final result is rounded with the caller-supplied scale and
+// rounding mode.
+package stats
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"tideland.dev/go/bcd"
+)
+
+// Statistics errors.
+var (
+	ErrNoValues           = fmt.Errorf("no values")
+	ErrInsufficientValues = fmt.Errorf("insufficient values")
+	ErrLengthMismatch     = fmt.Errorf("length mismatch")
+	ErrInvalidPercentile  = fmt.Errorf("invalid percentile")
+	ErrInvalidWeight      = fmt.Errorf("invalid weight")
+)
+
+var (
+	one        = bcd.Must(1)
+	oneHundred = bcd.Must(100)
+)
+
+// Sum returns the exact sum of all values. The sum of no values is zero.
+func Sum(values []*bcd.BCD) *bcd.BCD {
+	sum := bcd.Zero()
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of the values.
+func Mean(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	return Sum(values).Div(bcd.Must(len(values)), scale, mode)
+}
+
+// WeightedMean returns the mean of the values weighted by the weights
+// at the same index. Weights must not be negative and must not sum up
+// to zero.
+func WeightedMean(values, weights []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	if len(values) != len(weights) {
+		return nil, fmt.Errorf("%w: %d values, %d weights", ErrLengthMismatch, len(values), len(weights))
+	}
+
+	sum := bcd.Zero()
+	weightSum := bcd.Zero()
+	for i, v := range values {
+		w := weights[i]
+		if w.IsNegative() {
+			return nil, fmt.Errorf("%w: negative weight %s at index %d", ErrInvalidWeight, w, i)
+		}
+		sum = sum.Add(v.Mul(w))
+		weightSum = weightSum.Add(w)
+	}
+	if weightSum.IsZero() {
+		return nil, fmt.Errorf("%w: weights sum up to zero", ErrInvalidWeight)
+	}
+
+	return sum.Div(weightSum, scale, mode)
+}
+
+// Median returns the median of the values. For an even number of values
+// it is the mean of the two middle values.
+func Median(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	return Percentile(values, bcd.Must(50), scale, mode)
+}
+
+// Percentile returns the p-th percentile of the values with p between
+// 0 and 100. Between two ranks the result is interpolated linearly,
+// matching the inclusive definition used by most spreadsheets. The
+// result always has the given scale.
+func Percentile(values []*bcd.BCD, p *bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	if !p.IsFinite() || p.IsNegative() || p.GreaterThan(oneHundred) {
+		return nil, fmt.Errorf("%w: %s is outside of 0 to 100", ErrInvalidPercentile, p)
+	}
+
+	sorted := sortedCopy(values)
+
+	// The rank is (n-1) * p / 100, split into integer and fractional part.
+	rank := bcd.Must(len(sorted) - 1).Mul(p)
+	lower, err := rank.DivInt(oneHundred)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := lower.ToInt64()
+	if err != nil {
+		return nil, err
+	}
+	fraction := rank.Sub(lower.Mul(oneHundred))
+
+	// Interpolate between the rank and the following value, the
+	// fraction still carries the factor 100. Dividing also exact ranks
+	// returns all results with the requested scale.
+	result := sorted[idx].Mul(oneHundred)
+	if !fraction.IsZero() {
+		result = result.Add(sorted[idx+1].Sub(sorted[idx]).Mul(fraction))
+	}
+	return result.Div(oneHundred, scale, mode)
+}
+
+// Min returns the smallest of the values.
+func Min(values []*bcd.BCD) (*bcd.BCD, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v.LessThan(m) {
+			m = v
+		}
+	}
+	return m.Copy(), nil
+}
+
+// Max returns the largest of the values.
+func Max(values []*bcd.BCD) (*bcd.BCD, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	m := values[0]
+	for _, v := range values[1:] {
+		if v.GreaterThan(m) {
+			m = v
+		}
+	}
+	return m.Copy(), nil
+}
+
+// Variance returns the population variance of the values.
+func Variance(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	return accumulate(values).Variance(scale, mode)
+}
+
+// SampleVariance returns the sample variance of the values using
+// Bessel's correction. At least two values are needed.
+func SampleVariance(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	return accumulate(values).SampleVariance(scale, mode)
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	return accumulate(values).StdDev(scale, mode)
+}
+
+// SampleStdDev returns the sample standard deviation of the values.
+// At least two values are needed.
+func SampleStdDev(values []*bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	return accumulate(values).SampleStdDev(scale, mode)
+}
+
+// accumulate feeds all values into a new accumulator.
+func accumulate(values []*bcd.BCD) *Accumulator {
+	acc := NewAccumulator()
+	for _, v := range values {
+		acc.Add(v)
+	}
+	return acc
+}
+
+// sortedCopy returns the values sorted in ascending order without
+// touching the original slice.
+func sortedCopy(values []*bcd.BCD) []*bcd.BCD {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b *bcd.BCD) int {
+		return a.Cmp(b)
+	})
+	return sorted
+}
+
+// sqrtQuotient returns the square root of num / den rounded once to
+// the given scale. The quotient is truncated to 2*(scale+1) digits, an
+// inexact truncation is kept as a tiny additional digit so that the
+// root is still rounded correctly.
+func sqrtQuotient(num, den *bcd.BCD, scale int, mode bcd.RoundingMode) (*bcd.BCD, error) {
+	if scale < 0 {
+		scale = 0
+	}
+	q, err := num.Div(den, 2*scale+2, bcd.RoundDown)
+	if err != nil {
+		return nil, err
+	}
+	if !q.Mul(den).Equal(num) {
+		q = q.Add(bcd.Must("0." + strings.Repeat("0", 2*scale+3) + "1"))
+	}
+	return q.Sqrt(scale, mode)
+}
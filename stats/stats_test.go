@@ -0,0 +1,206 @@
+// Tideland Go BCD - Statistics - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package stats
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+)
+
+// values creates a slice of BCDs from strings.
+func values(ss ...string) []*bcd.BCD {
+	vs := make([]*bcd.BCD, len(ss))
+	for i, s := range ss {
+		vs[i] = bcd.Must(s)
+	}
+	return vs
+}
+
+func TestSumAndMean(t *testing.T) {
+	vs := values("0.1", "0.2", "0.3", "10.005")
+
+	verify.Equal(t, Sum(vs).String(), "10.605")
+	verify.Equal(t, Sum(nil).String(), "0")
+
+	mean, err := Mean(vs, 2, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, mean.String(), "2.65")
+
+	mean, err = Mean(values("1", "2", "2"), 4, bcd.RoundHalfUp)
+	verify.NoError(t, err)
+	verify.Equal(t, mean.String(), "1.6667")
+
+	_, err = Mean(nil, 2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrNoValues)
+}
+
+func TestWeightedMean(t *testing.T) {
+	vs := values("10", "20", "30")
+	ws := values("1", "2", "3")
+
+	mean, err := WeightedMean(vs, ws, 3, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, mean.String(), "23.333")
+
+	_, err = WeightedMean(vs, ws[:2], 3, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrLengthMismatch)
+
+	_, err = WeightedMean(vs, values("1", "-1", "1"), 3, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidWeight)
+
+	_, err = WeightedMean(vs, values("0", "0", "0"), 3, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidWeight)
+}
+
+func TestMedianAndPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []*bcd.BCD
+		p      string
+		want   string
+	}{
+		{"median odd", values("5", "1", "3"), "50", "3.000"},
+		{"median even", values("4", "1", "3", "2"), "50", "2.500"},
+		{"minimum", values("4", "1", "3", "2"), "0", "1.000"},
+		{"maximum", values("4", "1", "3", "2"), "100", "4.000"},
+		{"interpolated", values("10", "20", "30", "40", "50"), "90", "46.000"},
+		{"fractional rank", values("1", "2"), "33.3", "1.333"},
+		{"single value", values("7.25"), "75", "7.250"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Percentile(tt.values, bcd.Must(tt.p), 3, bcd.RoundHalfEven)
+			verify.NoError(t, err)
+			verify.Equal(t, got.String(), tt.want)
+		})
+	}
+
+	median, err := Median(values("0.01", "0.02"), 2, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, median.String(), "0.02")
+
+	_, err = Percentile(values("1"), bcd.Must("100.1"), 2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidPercentile)
+	_, err = Percentile(values("1"), bcd.NaN(), 2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidPercentile)
+	_, err = Percentile(values("1"), bcd.Inf(1), 2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidPercentile)
+}
+
+func TestMinMax(t *testing.T) {
+	vs := values("3.5", "-1.25", "12", "0")
+
+	minimum, err := Min(vs)
+	verify.NoError(t, err)
+	verify.Equal(t, minimum.String(), "-1.25")
+
+	maximum, err := Max(vs)
+	verify.NoError(t, err)
+	verify.Equal(t, maximum.String(), "12")
+
+	_, err = Min(nil)
+	verify.IsError(t, err, ErrNoValues)
+}
+
+func TestVarianceAndStdDev(t *testing.T) {
+	vs := values("2", "4", "4", "4", "5", "5", "7", "9")
+
+	variance, err := Variance(vs, 4, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, variance.Normalize().String(), "4")
+
+	stddev, err := StdDev(vs, 2, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.Normalize().String(), "2")
+
+	variance, err = SampleVariance(vs, 6, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, variance.String(), "4.571429")
+
+	stddev, err = SampleStdDev(vs, 10, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.String(), "2.1380899353")
+
+	_, err = SampleVariance(values("1"), 2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrInsufficientValues)
+}
+
+func TestStdDevRounding(t *testing.T) {
+	// The variance of 0 and 0.2 is 0.01, the root is exactly 0.1.
+	// Directed rounding must not move an exact result.
+	vs := values("0", "0.2")
+	for _, mode := range []bcd.RoundingMode{bcd.RoundUp, bcd.RoundCeiling, bcd.RoundDown} {
+		stddev, err := StdDev(vs, 3, mode)
+		verify.NoError(t, err)
+		verify.Equal(t, stddev.Normalize().String(), "0.1")
+	}
+
+	// The variance of 0 and 1 is 0.25, the population deviation 0.5;
+	// a tie when rounded to no places.
+	stddev, err := StdDev(values("0", "1"), 0, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.String(), "0")
+	stddev, err = StdDev(values("0", "1"), 0, bcd.RoundHalfUp)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.String(), "1")
+
+	// The variance of 0, 1, and 1 is 2/9, inexact in decimal. The root
+	// 0.4714... must still round up when truncation would drop digits.
+	stddev, err = StdDev(values("0", "1", "1"), 2, bcd.RoundUp)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.String(), "0.48")
+}
+
+func TestAccumulator(t *testing.T) {
+	acc := NewAccumulator()
+
+	_, err := acc.Mean(2, bcd.RoundHalfEven)
+	verify.IsError(t, err, ErrNoValues)
+	_, err = acc.Max()
+	verify.IsError(t, err, ErrNoValues)
+
+	acc.Add(values("2", "4", "4", "4")...)
+	other := NewAccumulator()
+	other.Add(values("5", "5", "7", "9")...)
+	acc.Merge(other)
+
+	verify.Equal(t, acc.Count(), int64(8))
+	verify.Equal(t, acc.Sum().String(), "40")
+
+	mean, err := acc.Mean(2, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, mean.String(), "5.00")
+
+	minimum, err := acc.Min()
+	verify.NoError(t, err)
+	verify.Equal(t, minimum.String(), "2")
+	maximum, err := acc.Max()
+	verify.NoError(t, err)
+	verify.Equal(t, maximum.String(), "9")
+
+	stddev, err := acc.StdDev(3, bcd.RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, stddev.String(), "2.000")
+
+	acc.Reset()
+	verify.Equal(t, acc.Count(), int64(0))
+	verify.Equal(t, acc.Sum().String(), "0")
+}
+
+func BenchmarkAccumulator(b *testing.B) {
+	vs := values("123.45", "678.90", "0.01", "99999.99", "-42.42")
+	acc := NewAccumulator()
+
+	for b.Loop() {
+		acc.Add(vs...)
+	}
+}
@@ -0,0 +1,260 @@
+// Tideland Go BCD - SWIFT
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+// Package swift formats and parses the amounts of SWIFT MT messages. The
+// amounts use the format 15d, digits with a mandatory decimal comma and
+// at most 15 characters, without sign or grouping. The fraction must not
+// exceed the decimal places of the currency. Besides single amounts the
+// composites of date, currency, and amount of the fields 32A and 33B
+// and the balances of 60F and its relatives are supported.
+package swift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tideland.dev/go/bcd"
+)
+
+// MaxAmountLength is the maximum length of an amount including the comma.
+const MaxAmountLength = 15
+
+// DateLayout is the layout of dates in the format 6!n. Following the Go
+// convention years 69 to 99 are in the 20th century, all others in the
+// 21st.
+const DateLayout = "060102"
+
+// SWIFT field errors.
+var (
+	ErrCharacterSet = fmt.Errorf("invalid character for SWIFT character set")
+	ErrFormat       = fmt.Errorf("invalid SWIFT field format")
+	ErrLength       = fmt.Errorf("invalid SWIFT field length")
+	ErrDecimals     = fmt.Errorf("too many decimals for currency")
+	ErrCurrency     = fmt.Errorf("invalid SWIFT currency")
+	ErrDate         = fmt.Errorf("invalid SWIFT date")
+	ErrSign         = fmt.Errorf("SWIFT amounts have no sign")
+)
+
+// FormatAmount returns the amount in the format 15d with the decimal
+// places of the currency, e.g. "1234,50" for EUR and "1500," for JPY.
+// Negative amounts return ErrSign, their sign is carried by separate
+// debit and credit marks.
+func FormatAmount(a *bcd.Amount) (string, error) {
+	if a == nil || a.Amount() == nil {
+		return "", fmt.Errorf("%w: missing amount", bcd.ErrInvalidAmount)
+	}
+	if a.IsNegative() {
+		return "", fmt.Errorf("%w: %s is negative", ErrSign, a.Format(false, true))
+	}
+	s := a.Format(false, false)
+	if a.DecimalPlaces() == 0 {
+		s += ","
+	} else {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	if len(s) > MaxAmountLength {
+		return "", fmt.Errorf("%w: amount %q exceeds %d characters", ErrLength, s, MaxAmountLength)
+	}
+	return s, nil
+}
+
+// ParseAmount parses an amount in the format 15d of the currency. The
+// number of digits after the comma must not exceed the decimal places
+// of the currency.
+func ParseAmount(s, code string) (*bcd.Amount, error) {
+	info, err := currencyInfo(code)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCharacters(s); err != nil {
+		return nil, err
+	}
+	if s == "" || len(s) > MaxAmountLength {
+		return nil, fmt.Errorf("%w: amount %q needs 1 to %d characters", ErrLength, s, MaxAmountLength)
+	}
+	integer, fraction, hasComma := strings.Cut(s, ",")
+	switch {
+	case !hasComma:
+		return nil, fmt.Errorf("%w: amount %q has no decimal comma", ErrFormat, s)
+	case integer == "":
+		return nil, fmt.Errorf("%w: amount %q has no integer digit", ErrFormat, s)
+	case !IsDigits(integer) || !IsDigits(fraction):
+		return nil, fmt.Errorf("%w: amount %q may only contain digits and one comma", ErrFormat, s)
+	case len(fraction) > info.DecimalPlaces:
+		return nil, fmt.Errorf("%w: amount %q has %d decimals, %s allows %d", ErrDecimals, s, len(fraction), info.Code, info.DecimalPlaces)
+	}
+	if fraction == "" {
+		return bcd.NewAmount(integer, info.Code)
+	}
+	return bcd.NewAmount(integer+"."+fraction, info.Code)
+}
+
+// FormatCurrencyAmount returns the amount in the format 3!a15d of field
+// 33B, e.g. "EUR1234,50".
+func FormatCurrencyAmount(a *bcd.Amount) (string, error) {
+	amount, err := FormatAmount(a)
+	if err != nil {
+		return "", err
+	}
+	return a.Code() + amount, nil
+}
+
+// ParseCurrencyAmount parses an amount in the format 3!a15d of field 33B.
+func ParseCurrencyAmount(s string) (*bcd.Amount, error) {
+	if err := checkCharacters(s); err != nil {
+		return nil, err
+	}
+	if len(s) < 4 {
+		return nil, fmt.Errorf("%w: %q is too short for currency and amount", ErrLength, s)
+	}
+	return ParseAmount(s[3:], s[:3])
+}
+
+// ValueDateAmount is the composite of value date, currency, and amount
+// of field 32A.
+type ValueDateAmount struct {
+	Date   time.Time
+	Amount *bcd.Amount
+}
+
+// Format returns the composite in the format 6!n3!a15d, e.g.
+// "250915EUR1234,50".
+func (v ValueDateAmount) Format() (string, error) {
+	amount, err := FormatCurrencyAmount(v.Amount)
+	if err != nil {
+		return "", err
+	}
+	return v.Date.Format(DateLayout) + amount, nil
+}
+
+// ParseValueDateAmount parses a composite in the format 6!n3!a15d of
+// field 32A.
+func ParseValueDateAmount(s string) (ValueDateAmount, error) {
+	if err := checkCharacters(s); err != nil {
+		return ValueDateAmount{}, err
+	}
+	if len(s) < 10 {
+		return ValueDateAmount{}, fmt.Errorf("%w: %q is too short for date, currency and amount", ErrLength, s)
+	}
+	date, err := ParseDate(s[:6])
+	if err != nil {
+		return ValueDateAmount{}, err
+	}
+	amount, err := ParseCurrencyAmount(s[6:])
+	if err != nil {
+		return ValueDateAmount{}, err
+	}
+	return ValueDateAmount{Date: date, Amount: amount}, nil
+}
+
+// Balance is the composite of debit or credit mark, date, currency, and
+// amount of the balance fields 60F, 60M, 62F, 62M, 64, and 65. Debit
+// balances have a negative amount. As amounts have no negative zero,
+// Debit keeps the mark of a zero balance.
+type Balance struct {
+	Date   time.Time
+	Amount *bcd.Amount
+	Debit  bool
+}
+
+// Format returns the balance in the format 1!a6!n3!a15d with the mark
+// D for debit or negative and C for other amounts, e.g.
+// "C250915EUR1234,50".
+func (b Balance) Format() (string, error) {
+	if b.Amount == nil {
+		return "", fmt.Errorf("%w: missing amount", bcd.ErrInvalidAmount)
+	}
+	mark := "C"
+	if b.Debit || b.Amount.IsNegative() {
+		mark = "D"
+	}
+	v := ValueDateAmount{Date: b.Date, Amount: b.Amount.Abs()}
+	s, err := v.Format()
+	if err != nil {
+		return "", err
+	}
+	return mark + s, nil
+}
+
+// ParseBalance parses a balance in the format 1!a6!n3!a15d.
+func ParseBalance(s string) (Balance, error) {
+	if err := checkCharacters(s); err != nil {
+		return Balance{}, err
+	}
+	if s == "" {
+		return Balance{}, fmt.Errorf("%w: empty balance", ErrLength)
+	}
+	mark := s[0]
+	if mark != 'C' && mark != 'D' {
+		return Balance{}, fmt.Errorf("%w: balance %q has no debit or credit mark", ErrFormat, s)
+	}
+	v, err := ParseValueDateAmount(s[1:])
+	if err != nil {
+		return Balance{}, err
+	}
+	if mark == 'D' {
+		v.Amount = v.Amount.Neg()
+	}
+	return Balance{Date: v.Date, Amount: v.Amount, Debit: mark == 'D'}, nil
+}
+
+// ParseDate parses a date in the format 6!n.
+func ParseDate(s string) (time.Time, error) {
+	if len(s) != len(DateLayout) || !IsDigits(s) {
+		return time.Time{}, fmt.Errorf("%w: %q is no date YYMMDD", ErrDate, s)
+	}
+	date, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q: %v", ErrDate, s, err)
+	}
+	return date, nil
+}
+
+// IsDigits returns true if the string only contains the digits 0 to 9,
+// like the fields of the character set n.
+func IsDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// currencyInfo returns the information of a currency in the format 3!a.
+func currencyInfo(code string) (bcd.CurrencyInfo, error) {
+	if len(code) != 3 || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return bcd.CurrencyInfo{}, fmt.Errorf("%w: %q is no code of three capital letters", ErrCurrency, code)
+	}
+	info, ok := bcd.GetCurrencyInfo(code)
+	if !ok {
+		return bcd.CurrencyInfo{}, fmt.Errorf("%w: unknown currency %q", ErrCurrency, code)
+	}
+	return info, nil
+}
+
+// checkCharacters checks that the field only contains characters of
+// the SWIFT X character set.
+func checkCharacters(s string) error {
+	for i := range len(s) {
+		if !isXCharacter(s[i]) {
+			return fmt.Errorf("%w: %q at position %d of %q", ErrCharacterSet, s[i], i+1, s)
+		}
+	}
+	return nil
+}
+
+// isXCharacter returns true if the byte belongs to the SWIFT X character
+// set of letters, digits, and / - ? : ( ) . , ' + space CR LF.
+func isXCharacter(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("/-?:().,'+ \r\n", c) >= 0
+}
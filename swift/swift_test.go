@@ -0,0 +1,166 @@
+// Tideland Go BCD - SWIFT - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package swift
+
+import (
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/bcd"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		value string
+		code  string
+		text  string
+	}{
+		{"1234.5", "EUR", "1234,50"},
+		{"0", "USD", "0,00"},
+		{"1500", "JPY", "1500,"},
+		{"999999999999.99", "EUR", "999999999999,99"},
+	}
+	for _, test := range tests {
+		a, err := bcd.NewAmount(test.value, test.code)
+		verify.NoError(t, err)
+		text, err := FormatAmount(a)
+		verify.NoError(t, err)
+		verify.Equal(t, text, test.text)
+	}
+
+	long, err := bcd.NewAmount("1000000000000", "EUR")
+	verify.NoError(t, err)
+	_, err = FormatAmount(long)
+	verify.IsError(t, err, ErrLength)
+	negative, err := bcd.NewAmount("-1", "EUR")
+	verify.NoError(t, err)
+	_, err = FormatAmount(negative)
+	verify.IsError(t, err, ErrSign)
+	_, err = FormatAmount(nil)
+	verify.IsError(t, err, bcd.ErrInvalidAmount)
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		text  string
+		code  string
+		value string
+	}{
+		{"1234,5", "EUR", "1234.50"},
+		{"1234,", "EUR", "1234.00"},
+		{"0,01", "EUR", "0.01"},
+		{"00100,", "JPY", "100"},
+		{"123456789012,34", "EUR", "123456789012.34"},
+	}
+	for _, test := range tests {
+		a, err := ParseAmount(test.text, test.code)
+		verify.NoError(t, err)
+		verify.Equal(t, a.Format(false, false), test.value)
+	}
+
+	invalid := []struct {
+		text string
+		code string
+		err  error
+	}{
+		{"", "EUR", ErrLength},
+		{"1234567890123,45", "EUR", ErrLength},
+		{"1234", "EUR", ErrFormat},
+		{",50", "EUR", ErrFormat},
+		{"1.234,50", "EUR", ErrFormat},
+		{"-12,50", "EUR", ErrFormat},
+		{"1 234,50", "EUR", ErrFormat},
+		{"12,5,0", "EUR", ErrFormat},
+		{"12;50", "EUR", ErrCharacterSet},
+		{"12,50€", "EUR", ErrCharacterSet},
+		{"12,505", "EUR", ErrDecimals},
+		{"12,0", "JPY", ErrDecimals},
+		{"12,50", "eur", ErrCurrency},
+		{"12,50", "ABC", ErrCurrency},
+	}
+	for _, test := range invalid {
+		_, err := ParseAmount(test.text, test.code)
+		verify.IsError(t, err, test.err)
+	}
+}
+
+func TestCurrencyAmount(t *testing.T) {
+	a, err := bcd.NewAmount("1234.5", "EUR")
+	verify.NoError(t, err)
+	text, err := FormatCurrencyAmount(a)
+	verify.NoError(t, err)
+	verify.Equal(t, text, "EUR1234,50")
+
+	a, err = ParseCurrencyAmount("USD1000,")
+	verify.NoError(t, err)
+	verify.Equal(t, a.Format(false, true), "1000.00 USD")
+
+	_, err = ParseCurrencyAmount("USD")
+	verify.IsError(t, err, ErrLength)
+	_, err = ParseCurrencyAmount("Usd1000,")
+	verify.IsError(t, err, ErrCurrency)
+}
+
+func TestValueDateAmount(t *testing.T) {
+	// Field 32A of an MT103
+	v, err := ParseValueDateAmount("250915EUR1958,47")
+	verify.NoError(t, err)
+	verify.Equal(t, v.Date, time.Date(2025, time.September, 15, 0, 0, 0, 0, time.UTC))
+	verify.Equal(t, v.Amount.Format(false, true), "1958.47 EUR")
+
+	text, err := v.Format()
+	verify.NoError(t, err)
+	verify.Equal(t, text, "250915EUR1958,47")
+
+	_, err = ParseValueDateAmount("250931EUR1958,47")
+	verify.IsError(t, err, ErrDate)
+	_, err = ParseValueDateAmount("25091EUR1958,47")
+	verify.IsError(t, err, ErrDate)
+	_, err = ParseValueDateAmount("250915")
+	verify.IsError(t, err, ErrLength)
+	_, err = ParseValueDateAmount("250915EUR1958.47")
+	verify.IsError(t, err, ErrFormat)
+}
+
+func TestBalance(t *testing.T) {
+	tests := []struct {
+		text  string
+		value string
+	}{
+		{"C250915EUR1234,56", "1234.56 EUR"},
+		{"D250915EUR1234,56", "-1234.56 EUR"},
+		{"C250915EUR0,00", "0.00 EUR"},
+		{"D250915EUR0,00", "0.00 EUR"},
+	}
+	for _, test := range tests {
+		b, err := ParseBalance(test.text)
+		verify.NoError(t, err)
+		verify.Equal(t, b.Amount.Format(false, true), test.value)
+		verify.Equal(t, b.Debit, test.text[0] == 'D')
+
+		text, err := b.Format()
+		verify.NoError(t, err)
+		verify.Equal(t, text, test.text)
+	}
+
+	_, err := ParseBalance("X250915EUR1234,56")
+	verify.IsError(t, err, ErrFormat)
+	_, err = ParseBalance("")
+	verify.IsError(t, err, ErrLength)
+	_, err = Balance{}.Format()
+	verify.IsError(t, err, bcd.ErrInvalidAmount)
+}
+
+func TestIsDigits(t *testing.T) {
+	verify.True(t, IsDigits("0123456789"))
+	verify.True(t, IsDigits(""))
+	verify.False(t, IsDigits("12,50"))
+	verify.False(t, IsDigits("-1"))
+}
@@ -0,0 +1,322 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// roundingModeNames contains the names of the rounding modes in the
+// order of their constants.
+var roundingModeNames = [...]string{
+	RoundDown:     "down",
+	RoundUp:       "up",
+	RoundHalfUp:   "half-up",
+	RoundHalfDown: "half-down",
+	RoundHalfEven: "half-even",
+	RoundCeiling:  "ceiling",
+	RoundFloor:    "floor",
+}
+
+// String returns the name of the rounding mode, e.g. "half-even".
+func (m RoundingMode) String() string {
+	if m < 0 || int(m) >= len(roundingModeNames) {
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+	return roundingModeNames[m]
+}
+
+// ParseRoundingMode returns the rounding mode with the given name as
+// returned by String. Case and underscores instead of hyphens are
+// ignored, so "HALF_EVEN" is accepted too.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", "-")
+	for m, n := range roundingModeNames {
+		if n == name {
+			return RoundingMode(m), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown rounding mode %q", ErrInvalidFormat, s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	if m < 0 || int(m) >= len(roundingModeNames) {
+		return nil, fmt.Errorf("%w: unknown rounding mode %d", ErrInvalidFormat, int(m))
+	}
+	return []byte(roundingModeNames[m]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// Set implements flag.Value.
+func (m *RoundingMode) Set(s string) error {
+	mode, err := ParseRoundingMode(s)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// ParseBytes parses a decimal like New does for strings, but without
+// converting the input into a string first. Plain decimals like
+// "-1234.56" are parsed directly from the bytes, only scientific notation
+// and special values take the way over a string.
+func ParseBytes(s []byte) (*BCD, error) {
+	if b, ok := parseBytes(s); ok {
+		return b, nil
+	}
+	return parseString(string(s))
+}
+
+// parseBytes parses a plain decimal with optional sign and decimal point.
+// It returns false for everything else, so the caller can fall back to
+// parseString.
+func parseBytes(s []byte) (*BCD, bool) {
+	s = bytes.TrimSpace(s)
+	negative := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	// Validate digits and find the decimal point
+	dot := -1
+	count := 0
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			count++
+		case c == '.' && dot < 0:
+			dot = i
+		default:
+			return nil, false
+		}
+	}
+	if count == 0 {
+		return nil, false
+	}
+
+	// Strip leading and trailing zeros like parseString
+	intPart, fracPart := s, []byte(nil)
+	if dot >= 0 {
+		intPart, fracPart = s[:dot], s[dot+1:]
+	}
+	intPart = bytes.TrimLeft(intPart, "0")
+	fracPart = bytes.TrimRight(fracPart, "0")
+	n := len(intPart) + len(fracPart)
+	if n == 0 {
+		if negative {
+			return NegZero(), true
+		}
+		return Zero(), true
+	}
+
+	// Create digit array (little-endian)
+	digits := make([]uint8, 0, n)
+	for i := len(fracPart) - 1; i >= 0; i-- {
+		digits = append(digits, fracPart[i]-'0')
+	}
+	for i := len(intPart) - 1; i >= 0; i-- {
+		digits = append(digits, intPart[i]-'0')
+	}
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	return &BCD{
+		digits:   digits,
+		scale:    len(fracPart),
+		negative: negative,
+	}, true
+}
+
+// AppendText implements encoding.TextAppender. It appends the string
+// representation to dst like strconv.AppendInt and never fails.
+func (b *BCD) AppendText(dst []byte) ([]byte, error) {
+	return b.appendString(dst, 0), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, so BCD values are
+// written as strings in configuration formats like YAML or TOML.
+func (b *BCD) MarshalText() ([]byte, error) {
+	return b.AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the same
+// formats as New for strings.
+func (b *BCD) UnmarshalText(text []byte) error {
+	parsed, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*b = *parsed
+	return nil
+}
+
+// Set implements flag.Value.
+func (b *BCD) Set(s string) error {
+	return b.UnmarshalText([]byte(s))
+}
+
+// Flag defines a BCD flag with the given name, default value and usage
+// on the command line flag set and returns a pointer to the value. Use
+// flag.FlagSet.Var for other flag sets, as *BCD is a flag.Value.
+func Flag(name string, value *BCD, usage string) *BCD {
+	b := Zero()
+	if value != nil {
+		b = value.Copy()
+	}
+	flag.Var(b, name, usage)
+	return b
+}
+
+// AppendFormat appends the value rounded to exactly the given number of
+// decimal places to dst, padding the fraction with zeros if needed.
+func (b *BCD) AppendFormat(dst []byte, places int, mode RoundingMode) []byte {
+	places = max(places, 0)
+	if b.form == finite && b.scale > places {
+		b = b.Round(places, mode)
+	}
+	return b.appendString(dst, places)
+}
+
+// Scan implements fmt.Scanner, so a BCD can be read with fmt.Fscan and
+// its relatives. It accepts the same formats as New for strings.
+func (b *BCD) Scan(state fmt.ScanState, verb rune) error {
+	if !strings.ContainsRune("vsdfFgGeE", verb) {
+		return fmt.Errorf("%w: unsupported verb %%%c", ErrInvalidFormat, verb)
+	}
+	token, err := scanToken(state, ErrInvalidFormat)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseBytes(token)
+	if err != nil {
+		return err
+	}
+	*b = *parsed
+	return nil
+}
+
+// scanToken reads the next decimal token. It returns io.EOF at the end
+// of the input and the given error if no decimal follows, so scanning
+// loops terminate.
+func scanToken(state fmt.ScanState, invalid error) ([]byte, error) {
+	token, err := state.Token(true, isDecimalRune)
+	if err != nil || len(token) > 0 {
+		return token, err
+	}
+	if _, _, err := state.ReadRune(); err != nil {
+		return nil, io.EOF
+	}
+	return nil, invalid
+}
+
+// isDecimalRune returns true for the runes of a decimal token including
+// exponents and the special values.
+func isDecimalRune(r rune) bool {
+	return r >= '0' && r <= '9' || r == '.' || r == '+' || r == '-' ||
+		r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// AppendText implements encoding.TextAppender. It appends the amount
+// followed by the currency code, e.g. "1234.50 EUR", which can be read
+// again by ParseAmount and Scan. The zero value of Amount returns
+// ErrInvalidAmount.
+func (c *Amount) AppendText(dst []byte) ([]byte, error) {
+	if c.amount == nil {
+		return nil, ErrInvalidAmount
+	}
+	return c.AppendFormat(dst, false, true), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, the text is the one
+// of AppendText.
+func (c *Amount) MarshalText() ([]byte, error) {
+	return c.AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all
+// formats of ParseAmount, e.g. "1234.50 EUR" or "€1.234,50".
+func (c *Amount) UnmarshalText(text []byte) error {
+	a, err := ParseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *a
+	return nil
+}
+
+// Set implements flag.Value.
+func (c *Amount) Set(s string) error {
+	return c.UnmarshalText([]byte(s))
+}
+
+// AmountFlag defines an amount flag with the given name, default value
+// and usage on the command line flag set and returns a pointer to the
+// value. Use flag.FlagSet.Var for other flag sets, as *Amount is a
+// flag.Value.
+func AmountFlag(name string, value *Amount, usage string) *Amount {
+	a := &Amount{}
+	if value != nil {
+		*a = *value
+	}
+	flag.Var(a, name, usage)
+	return a
+}
+
+// Scan implements fmt.Scanner. It reads the amount and the currency code
+// as two tokens in either order, e.g. "1234.50 EUR" or "EUR 1234.50".
+func (c *Amount) Scan(state fmt.ScanState, verb rune) error {
+	if !strings.ContainsRune("vsf", verb) {
+		return fmt.Errorf("%w: unsupported verb %%%c", ErrInvalidAmount, verb)
+	}
+	first, err := scanToken(state, ErrInvalidAmount)
+	if err != nil {
+		return err
+	}
+
+	// The token is only valid until the next read, so the first one
+	// is kept either as value or as code
+	var value *BCD
+	code := string(first)
+	if _, ok := GetCurrencyInfo(code); !ok {
+		code = ""
+		if value, err = ParseBytes(first); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+		}
+	}
+
+	second, err := scanToken(state, ErrInvalidAmount)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		if value, err = ParseBytes(second); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+		}
+	} else {
+		code = string(second)
+	}
+
+	a, err := NewAmount(value, code)
+	if err != nil {
+		return err
+	}
+	*c = *a
+	return nil
+}
@@ -0,0 +1,254 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []string{
+		"0", "-0", "+0", "00.000", "1", "-1", "+42", "1234.5600", "0.05",
+		"-.5", "1.", "  7.25  ", "000123.4500", "1e3", "-2.5E-2",
+		"NaN", "sNaN", "Inf", "-Infinity",
+	}
+	for _, s := range tests {
+		expected, err := New(s)
+		verify.NoError(t, err)
+		b, err := ParseBytes([]byte(s))
+		verify.NoError(t, err)
+		verify.True(t, expected.Identical(b), s)
+		verify.Equal(t, b.String(), expected.String(), s)
+	}
+
+	for _, s := range []string{"1.2.3", "12a", "--1", "1,5"} {
+		_, err := ParseBytes([]byte(s))
+		verify.IsError(t, err, ErrInvalidFormat)
+	}
+
+	// Plain decimals need only the digit slice and the BCD itself
+	input := []byte("-1234567.891")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ParseBytes(input)
+	})
+	verify.Equal(t, allocs, 2.0)
+}
+
+func TestAppendText(t *testing.T) {
+	tests := []struct {
+		value    *BCD
+		expected string
+	}{
+		{Must("1234.56"), "1234.56"},
+		{Must("-0.001"), "-0.001"},
+		{Zero(), "0"},
+		{NegZero(), "-0"},
+		{&BCD{digits: []uint8{0, 5, 1}, scale: 2}, "1.50"},
+		{Inf(-1), "-Inf"},
+		{NaN(), "NaN"},
+		{&BCD{}, "0"},
+	}
+	for _, test := range tests {
+		dst, err := test.value.AppendText([]byte("x="))
+		verify.NoError(t, err)
+		verify.Equal(t, string(dst), "x="+test.expected)
+		verify.Equal(t, test.value.String(), test.expected)
+	}
+
+	buf := make([]byte, 0, 64)
+	v := Must("-98765.4321")
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = v.AppendText(buf[:0])
+	})
+	verify.Equal(t, allocs, 0.0)
+}
+
+func TestAppendFormat(t *testing.T) {
+	tests := []struct {
+		value    string
+		places   int
+		mode     RoundingMode
+		expected string
+	}{
+		{"1.5", 2, RoundHalfEven, "1.50"},
+		{"12", 3, RoundHalfEven, "12.000"},
+		{"0", 2, RoundHalfEven, "0.00"},
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.5", 0, RoundHalfEven, "-2"},
+		{"0.999", 2, RoundHalfUp, "1.00"},
+		{"1.2", -1, RoundHalfEven, "1"},
+		{"Inf", 2, RoundHalfEven, "Inf"},
+	}
+	for _, test := range tests {
+		dst := Must(test.value).AppendFormat(nil, test.places, test.mode)
+		verify.Equal(t, string(dst), test.expected, test.value)
+	}
+}
+
+func TestAmountAppend(t *testing.T) {
+	a := MustNewAmount("-1234.5", "EUR")
+	dst, err := a.AppendText(nil)
+	verify.NoError(t, err)
+	verify.Equal(t, string(dst), "-1234.50 EUR")
+	verify.Equal(t, string(a.AppendFormat([]byte("= "), true, false)), "= -€1234.50")
+	verify.Equal(t, string(MustNewAmount(0, "USD").AppendFormat(nil, true, true)), "$0.00 USD")
+	verify.Equal(t, string(MustNewAmount(1500, "JPY").AppendFormat(nil, false, true)), "1500 JPY")
+
+	p, err := ParseAmount(string(dst))
+	verify.NoError(t, err)
+	verify.True(t, p.Equal(a))
+}
+
+func TestScan(t *testing.T) {
+	var a, b, c BCD
+	var d *BCD = Zero()
+	n, err := fmt.Sscan("1.50 -2e2 NaN\n  0.125", &a, &b, &c, d)
+	verify.NoError(t, err)
+	verify.Equal(t, n, 4)
+	verify.Equal(t, a.String(), "1.5")
+	verify.Equal(t, b.String(), "-200")
+	verify.True(t, c.IsNaN())
+	verify.Equal(t, d.String(), "0.125")
+
+	_, err = fmt.Sscan("12x", &a)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = fmt.Sscanf("7", "%x", &a)
+	verify.IsError(t, err, ErrInvalidFormat)
+
+	var total Sum
+	r := strings.NewReader("10.25\n-0.25\n3\n")
+	for {
+		var v BCD
+		if _, err := fmt.Fscan(r, &v); err != nil {
+			break
+		}
+		total.Add(&v)
+	}
+	verify.Equal(t, total.Result().String(), "13.00")
+	verify.Equal(t, total.Count(), 3)
+}
+
+func TestScanAmount(t *testing.T) {
+	var a, b Amount
+	n, err := fmt.Sscan("12.5 EUR USD -3", &a, &b)
+	verify.NoError(t, err)
+	verify.Equal(t, n, 2)
+	verify.Equal(t, a.Format(false, true), "12.50 EUR")
+	verify.Equal(t, b.Format(false, true), "-3.00 USD")
+
+	_, err = fmt.Sscan("12.5 XXX", &a)
+	verify.IsError(t, err, ErrUnknownCurrency)
+	_, err = fmt.Sscan("EUR abc", &a)
+	verify.IsError(t, err, ErrInvalidAmount)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = fmt.Sscan("12.5", &a)
+	verify.Error(t, err)
+}
+
+func TestRoundingModeText(t *testing.T) {
+	for m := RoundDown; m <= RoundFloor; m++ {
+		text, err := m.MarshalText()
+		verify.NoError(t, err)
+		verify.Equal(t, string(text), m.String())
+		var parsed RoundingMode
+		verify.NoError(t, parsed.UnmarshalText(text))
+		verify.Equal(t, parsed, m)
+	}
+	verify.Equal(t, RoundHalfEven.String(), "half-even")
+	verify.Equal(t, RoundingMode(42).String(), "RoundingMode(42)")
+	_, err := RoundingMode(-1).MarshalText()
+	verify.IsError(t, err, ErrInvalidFormat)
+
+	m, err := ParseRoundingMode(" HALF_UP ")
+	verify.NoError(t, err)
+	verify.Equal(t, m, RoundHalfUp)
+	_, err = ParseRoundingMode("nearest")
+	verify.IsError(t, err, ErrInvalidFormat)
+}
+
+func TestMarshalText(t *testing.T) {
+	type config struct {
+		Threshold *BCD         `json:"threshold"`
+		Fee       *Amount      `json:"fee"`
+		Mode      RoundingMode `json:"mode"`
+	}
+	in := config{
+		Threshold: Must("0.0025"),
+		Fee:       MustNewAmount("1.5", "EUR"),
+		Mode:      RoundCeiling,
+	}
+	data, err := json.Marshal(in)
+	verify.NoError(t, err)
+	verify.Equal(t, string(data), `{"threshold":"0.0025","fee":"1.50 EUR","mode":"ceiling"}`)
+
+	var out config
+	verify.NoError(t, json.Unmarshal(data, &out))
+	verify.True(t, out.Threshold.Identical(in.Threshold))
+	verify.True(t, out.Fee.Equal(in.Fee))
+	verify.Equal(t, out.Mode, RoundCeiling)
+
+	text, err := MustNewAmount(-1500, "JPY").MarshalText()
+	verify.NoError(t, err)
+	verify.Equal(t, string(text), "-1500 JPY")
+
+	var b BCD
+	verify.IsError(t, b.UnmarshalText([]byte("1.2.3")), ErrInvalidFormat)
+	var a Amount
+	verify.IsError(t, a.UnmarshalText([]byte("12")), ErrInvalidAmount)
+	verify.NoError(t, a.UnmarshalText([]byte("€1.234,56")))
+	verify.Equal(t, a.Format(false, true), "1234.56 EUR")
+	_, err = (&Amount{}).MarshalText()
+	verify.IsError(t, err, ErrInvalidAmount)
+}
+
+func TestFlagValues(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	limit := Must("100")
+	fee := MustNewAmount("0.30", "EUR")
+	var bonus Amount
+	mode := RoundHalfEven
+	fs.Var(limit, "limit", "limit")
+	fs.Var(fee, "fee", "fee")
+	fs.Var(&bonus, "bonus", "bonus")
+	fs.Var(&mode, "mode", "mode")
+
+	err := fs.Parse([]string{"--limit", "2.5e3", "--fee", "0.45 USD", "--mode", "floor"})
+	verify.NoError(t, err)
+	verify.Equal(t, limit.String(), "2500")
+	verify.Equal(t, fee.Format(false, true), "0.45 USD")
+	verify.Equal(t, mode, RoundFloor)
+	verify.Equal(t, bonus.String(), "")
+
+	err = fs.Parse([]string{"--limit", "abc"})
+	verify.Error(t, err)
+	fs.PrintDefaults()
+	verify.False(t, strings.Contains(out.String(), "panic"))
+
+	// Flag and AmountFlag register on the command line and copy defaults
+	def := Must("10")
+	f := Flag("bcd-test-max", def, "maximum")
+	verify.NoError(t, flag.Set("bcd-test-max", "12.5"))
+	verify.Equal(t, f.String(), "12.5")
+	verify.Equal(t, def.String(), "10")
+	verify.Equal(t, flag.Lookup("bcd-test-max").DefValue, "10")
+
+	a := AmountFlag("bcd-test-fee", nil, "fee")
+	verify.NoError(t, flag.Set("bcd-test-fee", "CHF 2.50"))
+	verify.Equal(t, a.Format(false, true), "2.50 CHF")
+}
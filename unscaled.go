@@ -0,0 +1,189 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import "fmt"
+
+// Maximum precisions of the int32 and int64 physical types of the
+// Parquet decimal logical type.
+const (
+	maxInt32Precision = 9
+	maxInt64Precision = 18
+)
+
+// ToUnscaledBytes returns the value as unscaled integer in big-endian
+// two's complement like the decimal logical type of Avro and Parquet.
+// The value is rounded to the scale with the given mode and must not
+// have more than precision digits, otherwise ErrOverflow is returned.
+// With a fixedLen of 0 the minimal number of bytes is returned like for
+// Avro bytes, otherwise exactly fixedLen bytes like for Avro fixed and
+// Parquet FIXED_LEN_BYTE_ARRAY.
+func (b *BCD) ToUnscaledBytes(precision, scale, fixedLen int, mode RoundingMode) ([]byte, error) {
+	digits, negative, err := b.unscaledDigits(precision, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	if fixedLen < 0 {
+		return nil, fmt.Errorf("%w: negative fixed length %d", ErrInvalidOperation, fixedLen)
+	}
+	data := twosComplement(magnitudeBytes(digits), negative)
+	if fixedLen == 0 {
+		return data, nil
+	}
+	if len(data) > fixedLen {
+		return nil, fmt.Errorf("%w: %s needs more than %d bytes", ErrOverflow, b, fixedLen)
+	}
+
+	// Sign-extend to the fixed length
+	fill := byte(0)
+	if negative {
+		fill = 0xff
+	}
+	fixed := make([]byte, fixedLen)
+	pad := fixedLen - len(data)
+	for i := range pad {
+		fixed[i] = fill
+	}
+	copy(fixed[pad:], data)
+	return fixed, nil
+}
+
+// FromUnscaledBytes creates a BCD from an unscaled integer in big-endian
+// two's complement and its scale, e.g. of the decimal logical type of
+// Avro and Parquet.
+func FromUnscaledBytes(data []byte, scale int) (*BCD, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty unscaled integer", ErrInvalidFormat)
+	}
+	if scale < 0 {
+		return nil, fmt.Errorf("%w: negative scale %d", ErrInvalidOperation, scale)
+	}
+	negative := data[0]&0x80 != 0
+	magnitude := data
+	if negative {
+		magnitude = negateBytes(data)
+	}
+	return fromUnscaledDigits(digitsFromBytes(magnitude), negative, scale), nil
+}
+
+// ToUnscaledInt32 returns the value as unscaled int32 for the int32
+// physical type of Parquet, which supports precisions up to 9.
+func (b *BCD) ToUnscaledInt32(precision, scale int, mode RoundingMode) (int32, error) {
+	if precision > maxInt32Precision {
+		return 0, fmt.Errorf("%w: precision %d exceeds %d of int32", ErrInvalidOperation, precision, maxInt32Precision)
+	}
+	n, err := b.ToUnscaledInt64(precision, scale, mode)
+	return int32(n), err
+}
+
+// FromUnscaledInt32 creates a BCD from an unscaled int32 and its scale.
+func FromUnscaledInt32(n int32, scale int) (*BCD, error) {
+	return FromUnscaledInt64(int64(n), scale)
+}
+
+// ToUnscaledInt64 returns the value as unscaled int64 for the int64
+// physical type of Parquet, which supports precisions up to 18.
+func (b *BCD) ToUnscaledInt64(precision, scale int, mode RoundingMode) (int64, error) {
+	if precision > maxInt64Precision {
+		return 0, fmt.Errorf("%w: precision %d exceeds %d of int64", ErrInvalidOperation, precision, maxInt64Precision)
+	}
+	digits, negative, err := b.unscaledDigits(precision, scale, mode)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for i := len(digits) - 1; i >= 0; i-- {
+		n = n*10 + int64(digits[i])
+	}
+	if negative {
+		n = -n
+	}
+	return n, nil
+}
+
+// FromUnscaledInt64 creates a BCD from an unscaled int64 and its scale.
+func FromUnscaledInt64(n int64, scale int) (*BCD, error) {
+	if scale < 0 {
+		return nil, fmt.Errorf("%w: negative scale %d", ErrInvalidOperation, scale)
+	}
+	b := fromInt64(n)
+	return fromUnscaledDigits(b.digits, b.negative, scale), nil
+}
+
+// unscaledDigits returns the digits of the value rounded to the scale
+// as integer, checked against the precision.
+func (b *BCD) unscaledDigits(precision, scale int, mode RoundingMode) ([]uint8, bool, error) {
+	r, shift, err := b.rescale(precision, scale, mode)
+	if err != nil {
+		return nil, false, err
+	}
+	n := significantLength(r.digits)
+	if n == 0 {
+		return []uint8{0}, false, nil
+	}
+	digits := make([]uint8, shift+n)
+	copy(digits[shift:], r.digits[:n])
+	return digits, r.negative, nil
+}
+
+// rescale rounds the value to the scale and checks it against the
+// precision. It returns the rounded value and the number of zeros
+// to append to its digits for the scale.
+func (b *BCD) rescale(precision, scale int, mode RoundingMode) (*BCD, int, error) {
+	switch {
+	case !b.IsFinite():
+		return nil, 0, fmt.Errorf("%w: %s has no unscaled form", ErrInvalidOperation, b)
+	case precision < 1 || scale < 0 || scale > precision:
+		return nil, 0, fmt.Errorf("%w: invalid precision %d and scale %d", ErrInvalidOperation, precision, scale)
+	}
+	r := b.Round(scale, mode)
+	shift := scale - r.scale
+	if n := significantLength(r.digits); n > 0 && n+shift > precision {
+		return nil, 0, fmt.Errorf("%w: %s exceeds precision %d with scale %d", ErrOverflow, b, precision, scale)
+	}
+	return r, shift, nil
+}
+
+// fromUnscaledDigits creates a BCD from the digits of an unscaled
+// integer and its scale.
+func fromUnscaledDigits(digits []uint8, negative bool, scale int) *BCD {
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	return &BCD{digits: digits, scale: scale, negative: negative && !isZero(digits)}
+}
+
+// twosComplement returns the big-endian magnitude as two's complement
+// with the given sign and without redundant sign bytes. Zero is a single
+// zero byte.
+func twosComplement(magnitude []byte, negative bool) []byte {
+	switch {
+	case !negative && (len(magnitude) == 0 || magnitude[0]&0x80 != 0):
+		return append([]byte{0}, magnitude...)
+	case !negative:
+		return magnitude
+	}
+	out := negateBytes(magnitude)
+	if out[0]&0x80 == 0 {
+		out = append([]byte{0xff}, out...)
+	}
+	return out
+}
+
+// negateBytes returns the negation of big-endian two's complement data,
+// which also turns a negative value into its unsigned magnitude.
+func negateBytes(data []byte) []byte {
+	out := make([]byte, len(data))
+	carry := 1
+	for i := len(data) - 1; i >= 0; i-- {
+		v := int(^data[i]) + carry
+		out[i] = byte(v)
+		carry = v >> 8
+	}
+	return out
+}
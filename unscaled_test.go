@@ -0,0 +1,130 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestUnscaledBytes(t *testing.T) {
+	tests := []struct {
+		value     string
+		precision int
+		scale     int
+		fixedLen  int
+		mode      RoundingMode
+		bytes     string
+		back      string
+	}{
+		// Unscaled 0, 1, -1, 127, 128, -128, -129, 255 and -255
+		{"0", 9, 2, 0, RoundHalfEven, "00", "0"},
+		{"0.01", 9, 2, 0, RoundHalfEven, "01", "0.01"},
+		{"-0.01", 9, 2, 0, RoundHalfEven, "ff", "-0.01"},
+		{"1.27", 9, 2, 0, RoundHalfEven, "7f", "1.27"},
+		{"1.28", 9, 2, 0, RoundHalfEven, "0080", "1.28"},
+		{"-1.28", 9, 2, 0, RoundHalfEven, "80", "-1.28"},
+		{"-1.29", 9, 2, 0, RoundHalfEven, "ff7f", "-1.29"},
+		{"2.55", 9, 2, 0, RoundHalfEven, "00ff", "2.55"},
+		{"-2.55", 9, 2, 0, RoundHalfEven, "ff01", "-2.55"},
+		{"-2.56", 9, 2, 0, RoundHalfEven, "ff00", "-2.56"},
+		// Rescaling and rounding
+		{"1.5", 5, 3, 0, RoundHalfEven, "05dc", "1.500"},
+		{"1.005", 5, 2, 0, RoundHalfEven, "64", "1.00"},
+		{"1.005", 5, 2, 0, RoundHalfUp, "65", "1.01"},
+		{"-1.005", 5, 2, 0, RoundFloor, "9b", "-1.01"},
+		{"-0.004", 5, 2, 0, RoundHalfEven, "00", "0"},
+		// Fixed lengths are sign-extended
+		{"1234.5678", 20, 4, 9, RoundHalfEven, "000000000000bc614e", "1234.5678"},
+		{"-1234.5678", 20, 4, 9, RoundHalfEven, "ffffffffffff439eb2", "-1234.5678"},
+		// Precision 38 needs 16 bytes like Spark and Hive
+		{"99999999999999999999999999999999999999", 38, 0, 16, RoundHalfEven, "4b3b4ca85a86c47a098a223fffffffff", "99999999999999999999999999999999999999"},
+		{"-99999999999999999999999999999999999999", 38, 0, 16, RoundHalfEven, "b4c4b357a5793b85f675ddc000000001", "-99999999999999999999999999999999999999"},
+	}
+	for _, test := range tests {
+		data, err := Must(test.value).ToUnscaledBytes(test.precision, test.scale, test.fixedLen, test.mode)
+		verify.NoError(t, err)
+		verify.Equal(t, hex.EncodeToString(data), test.bytes, test.value)
+
+		back, err := FromUnscaledBytes(data, test.scale)
+		verify.NoError(t, err)
+		verify.Equal(t, back.String(), test.back, test.value)
+		verify.Equal(t, back.Scale(), test.scale)
+	}
+}
+
+func TestUnscaledErrors(t *testing.T) {
+	tests := []struct {
+		value     *BCD
+		precision int
+		scale     int
+		fixedLen  int
+		err       error
+	}{
+		{Must("1000"), 5, 2, 0, ErrOverflow},
+		{Must("999.995"), 5, 2, 0, ErrOverflow},
+		{Must("327.68"), 5, 2, 2, ErrOverflow},
+		{Must("-327.68"), 5, 2, 2, nil},
+		{Must("-327.69"), 5, 2, 2, ErrOverflow},
+		{Must("1"), 0, 0, 0, ErrInvalidOperation},
+		{Must("1"), 2, 3, 0, ErrInvalidOperation},
+		{Must("1"), 2, -1, 0, ErrInvalidOperation},
+		{Must("1"), 2, 1, -1, ErrInvalidOperation},
+		{NaN(), 5, 2, 0, ErrInvalidOperation},
+		{Inf(1), 5, 2, 0, ErrInvalidOperation},
+	}
+	for _, test := range tests {
+		_, err := test.value.ToUnscaledBytes(test.precision, test.scale, test.fixedLen, RoundHalfEven)
+		if test.err == nil {
+			verify.NoError(t, err)
+			continue
+		}
+		verify.IsError(t, err, test.err)
+	}
+
+	_, err := FromUnscaledBytes(nil, 2)
+	verify.IsError(t, err, ErrInvalidFormat)
+	_, err = FromUnscaledBytes([]byte{1}, -1)
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func TestUnscaledInts(t *testing.T) {
+	n32, err := Must("-12345.678").ToUnscaledInt32(9, 2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, n32, int32(-1234568))
+	b, err := FromUnscaledInt32(n32, 2)
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "-12345.68")
+
+	_, err = Must("1").ToUnscaledInt32(10, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+	_, err = Must("10000000").ToUnscaledInt32(9, 2, RoundHalfEven)
+	verify.IsError(t, err, ErrOverflow)
+
+	n64, err := Must("9999999999999999.99").ToUnscaledInt64(18, 2, RoundHalfEven)
+	verify.NoError(t, err)
+	verify.Equal(t, n64, int64(999999999999999999))
+	b, err = FromUnscaledInt64(n64, 2)
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "9999999999999999.99")
+
+	_, err = Must("1").ToUnscaledInt64(19, 0, RoundHalfEven)
+	verify.IsError(t, err, ErrInvalidOperation)
+
+	// The full int64 range can be read
+	b, err = FromUnscaledInt64(math.MinInt64, 4)
+	verify.NoError(t, err)
+	verify.Equal(t, b.String(), "-922337203685477.5808")
+	b, err = FromUnscaledInt64(0, 3)
+	verify.NoError(t, err)
+	verify.True(t, b.IsZero())
+	verify.Equal(t, b.Scale(), 3)
+}
@@ -0,0 +1,566 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordsMaxDigits is the maximum number of integer digits of amounts
+// spelled out by Words. The largest magnitudes named are the decillion
+// in English, the Quintilliarde in German, the quintilliard in French,
+// and the quintillón in Spanish.
+const WordsMaxDigits = 36
+
+// WordsOption represents optional parameters for Words.
+type WordsOption func(*wordsOptions)
+
+type wordsOptions struct {
+	chequeFraction bool
+}
+
+// WithChequeFraction writes the minor units as fraction like on cheques,
+// e.g. "and 56/100" instead of "and fifty-six cents".
+func WithChequeFraction() WordsOption {
+	return func(o *wordsOptions) {
+		o.chequeFraction = true
+	}
+}
+
+// currencyUnits contains the names of the major and minor unit of a
+// currency in one language. The gender is needed for the French and
+// Spanish one.
+type currencyUnits struct {
+	major, majorPlural string
+	minor, minorPlural string
+	majorFeminine      bool
+	minorFeminine      bool
+}
+
+// wordsLanguage contains the rules to spell out amounts in a language.
+type wordsLanguage struct {
+	// cardinal returns the words of the number given as groups of three
+	// digits, least significant first.
+	cardinal func(groups []int, feminine bool) string
+	// of returns the preposition between a number ending with millions
+	// or higher and the unit, e.g. "d'" in "un million d'euros".
+	of           func(groups []int, unit string) string
+	and          string
+	minus        string
+	singularZero bool
+	capitalize   bool
+	units        map[string]currencyUnits
+}
+
+// Words returns the amount spelled out in the language "en", "de", "fr"
+// or "es" with the names of the major and minor units of the currency,
+// e.g. "One thousand two hundred thirty-four dollars and fifty-six cents"
+// or "eintausendzweihundertvierunddreißig Euro und sechsundfünfzig Cent".
+// Zero minor units are omitted unless WithChequeFraction is set. For
+// currencies without names in the language the currency code is used as
+// unit and the minor units are written as fraction. Unknown languages
+// return ErrInvalidOperation, amounts with more than WordsMaxDigits
+// integer digits ErrOverflow.
+func (c *Amount) Words(lang string, opts ...WordsOption) (string, error) {
+	if c == nil || c.amount == nil || !c.amount.IsFinite() {
+		return "", ErrInvalidAmount
+	}
+	language, ok := wordsLanguages[lang]
+	if !ok {
+		return "", fmt.Errorf("%w: no words for language %q", ErrInvalidOperation, lang)
+	}
+	o := &wordsOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	b := c.amount
+	n := significantLength(b.digits) - b.scale
+	if n > WordsMaxDigits {
+		return "", fmt.Errorf("%w: %s exceeds %d integer digits for words", ErrOverflow, c, WordsMaxDigits)
+	}
+	units, ok := language.units[c.info.Code]
+	if !ok {
+		units = currencyUnits{major: c.info.Code, majorPlural: c.info.Code}
+	}
+
+	// Integer part in groups of three digits and the minor units.
+	groups := make([]int, (max(n, 1)+2)/3)
+	for i := range max(n, 0) {
+		groups[i/3] += int(digitAt(b, b.scale+i)) * int(powersOfTen[i%3])
+	}
+	places := c.info.DecimalPlaces
+	minor := 0
+	for i := places - 1; i >= 0; i-- {
+		minor = minor*10 + int(digitAt(b, b.scale-places+i))
+	}
+
+	var sb strings.Builder
+	if b.IsNegative() {
+		sb.WriteString(language.minus)
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(language.cardinal(groups, units.majorFeminine))
+	sb.WriteByte(' ')
+	sb.WriteString(language.unit(groups, units.major, units.majorPlural))
+	switch {
+	case places == 0:
+	case o.chequeFraction || units.minor == "":
+		fmt.Fprintf(&sb, " %s %0*d/1%s", language.and, places, minor, strings.Repeat("0", places))
+	case minor != 0:
+		minorGroups := numberGroups(minor)
+		sb.WriteByte(' ')
+		sb.WriteString(language.and)
+		sb.WriteByte(' ')
+		sb.WriteString(language.cardinal(minorGroups, units.minorFeminine))
+		sb.WriteByte(' ')
+		sb.WriteString(language.unit(minorGroups, units.minor, units.minorPlural))
+	}
+	words := sb.String()
+	if language.capitalize {
+		r, size := utf8.DecodeRuneInString(words)
+		words = string(unicode.ToUpper(r)) + words[size:]
+	}
+	return words, nil
+}
+
+// unit returns the singular or plural name of the unit for the number
+// including a needed preposition.
+func (l *wordsLanguage) unit(groups []int, singular, plural string) string {
+	name := plural
+	if isOneGroups(groups) || (l.singularZero && isZeroGroups(groups)) {
+		name = singular
+	}
+	if l.of != nil {
+		return l.of(groups, name) + name
+	}
+	return name
+}
+
+// wordsLanguages contains the rules of the supported languages.
+var wordsLanguages = map[string]*wordsLanguage{
+	"en": {
+		cardinal:   englishCardinal,
+		and:        "and",
+		minus:      "minus",
+		capitalize: true,
+		units: map[string]currencyUnits{
+			"USD": enDollar, "CAD": enDollar, "AUD": enDollar, "NZD": enDollar, "SGD": enDollar, "HKD": enDollar,
+			"EUR": {major: "euro", majorPlural: "euros", minor: "cent", minorPlural: "cents"},
+			"GBP": {major: "pound", majorPlural: "pounds", minor: "penny", minorPlural: "pence"},
+			"CHF": {major: "franc", majorPlural: "francs", minor: "centime", minorPlural: "centimes"},
+			"JPY": {major: "yen", majorPlural: "yen"},
+			"MXN": {major: "peso", majorPlural: "pesos", minor: "centavo", minorPlural: "centavos"},
+		},
+	},
+	"de": {
+		cardinal: germanCardinal,
+		and:      "und",
+		minus:    "minus",
+		units: map[string]currencyUnits{
+			"USD": deDollar, "CAD": deDollar, "AUD": deDollar, "NZD": deDollar, "SGD": deDollar, "HKD": deDollar,
+			"EUR": {major: "Euro", majorPlural: "Euro", minor: "Cent", minorPlural: "Cent"},
+			"GBP": {major: "Pfund", majorPlural: "Pfund", minor: "Penny", minorPlural: "Pence"},
+			"CHF": {major: "Franken", majorPlural: "Franken", minor: "Rappen", minorPlural: "Rappen"},
+			"JPY": {major: "Yen", majorPlural: "Yen"},
+			"MXN": {major: "Peso", majorPlural: "Pesos", minor: "Centavo", minorPlural: "Centavos"},
+		},
+	},
+	"fr": {
+		cardinal:     frenchCardinal,
+		of:           frenchOf,
+		and:          "et",
+		minus:        "moins",
+		singularZero: true,
+		units: map[string]currencyUnits{
+			"USD": frDollar, "CAD": frDollar, "AUD": frDollar, "NZD": frDollar, "SGD": frDollar, "HKD": frDollar,
+			"EUR": {major: "euro", majorPlural: "euros", minor: "centime", minorPlural: "centimes"},
+			"GBP": {major: "livre", majorPlural: "livres", minor: "penny", minorPlural: "pence", majorFeminine: true},
+			"CHF": {major: "franc", majorPlural: "francs", minor: "centime", minorPlural: "centimes"},
+			"JPY": {major: "yen", majorPlural: "yens"},
+			"MXN": {major: "peso", majorPlural: "pesos", minor: "centavo", minorPlural: "centavos"},
+		},
+	},
+	"es": {
+		cardinal: spanishCardinal,
+		of:       spanishOf,
+		and:      "con",
+		minus:    "menos",
+		units: map[string]currencyUnits{
+			"USD": esDollar, "CAD": esDollar, "AUD": esDollar, "NZD": esDollar, "SGD": esDollar, "HKD": esDollar,
+			"EUR": {major: "euro", majorPlural: "euros", minor: "céntimo", minorPlural: "céntimos"},
+			"GBP": {major: "libra", majorPlural: "libras", minor: "penique", minorPlural: "peniques", majorFeminine: true},
+			"CHF": {major: "franco", majorPlural: "francos", minor: "céntimo", minorPlural: "céntimos"},
+			"JPY": {major: "yen", majorPlural: "yenes"},
+			"MXN": {major: "peso", majorPlural: "pesos", minor: "centavo", minorPlural: "centavos"},
+		},
+	},
+}
+
+// Units of the dollar currencies.
+var (
+	enDollar = currencyUnits{major: "dollar", majorPlural: "dollars", minor: "cent", minorPlural: "cents"}
+	deDollar = currencyUnits{major: "Dollar", majorPlural: "Dollar", minor: "Cent", minorPlural: "Cent"}
+	frDollar = currencyUnits{major: "dollar", majorPlural: "dollars", minor: "cent", minorPlural: "cents"}
+	esDollar = currencyUnits{major: "dólar", majorPlural: "dólares", minor: "centavo", minorPlural: "centavos"}
+)
+
+// English uses the short scale and hyphens between tens and ones.
+var (
+	enOnes = [...]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens   = [...]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	enScales = [...]string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+		"sextillion", "septillion", "octillion", "nonillion", "decillion"}
+)
+
+// englishCardinal returns the English words of the number, e.g. "two
+// thousand three hundred forty-five".
+func englishCardinal(groups []int, _ bool) string {
+	if isZeroGroups(groups) {
+		return enOnes[0]
+	}
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		words = append(words, englishHundreds(groups[i]))
+		if i > 0 {
+			words = append(words, enScales[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// englishHundreds returns the English words of 1 to 999.
+func englishHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, enOnes[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		words = append(words, enTens[n/10]+"-"+enOnes[n%10])
+	case n >= 20:
+		words = append(words, enTens[n/10])
+	case n > 0:
+		words = append(words, enOnes[n])
+	}
+	return strings.Join(words, " ")
+}
+
+// German writes numbers below a million as one word, the long scale
+// names from Million on are nouns with singular and plural.
+var (
+	deOnes = [...]string{"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+	deTens   = [...]string{"", "zehn", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+	deScales = [...][2]string{{}, {}, {"Million", "Millionen"}, {"Milliarde", "Milliarden"},
+		{"Billion", "Billionen"}, {"Billiarde", "Billiarden"}, {"Trillion", "Trillionen"},
+		{"Trilliarde", "Trilliarden"}, {"Quadrillion", "Quadrillionen"}, {"Quadrilliarde", "Quadrilliarden"},
+		{"Quintillion", "Quintillionen"}, {"Quintilliarde", "Quintilliarden"}}
+)
+
+// germanCardinal returns the German words of the number before its unit,
+// e.g. "zwei Millionen dreitausendein". A trailing one is inflected to
+// "ein" or "eine".
+func germanCardinal(groups []int, feminine bool) string {
+	one := "ein"
+	if feminine {
+		one = "eine"
+	}
+	if isZeroGroups(groups) {
+		return deOnes[0]
+	}
+	if isOneGroups(groups) {
+		return one
+	}
+	var words []string
+	for i := len(groups) - 1; i >= 2; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		if groups[i] == 1 {
+			words = append(words, "eine", deScales[i][0])
+			continue
+		}
+		words = append(words, germanInflected(germanHundreds(groups[i]), "eine"), deScales[i][1])
+	}
+	var low string
+	if len(groups) > 1 && groups[1] > 0 {
+		low = germanInflected(germanHundreds(groups[1]), "ein") + "tausend"
+	}
+	if groups[0] > 0 {
+		low += germanInflected(germanHundreds(groups[0]), one)
+	}
+	if low != "" {
+		words = append(words, low)
+	}
+	return strings.Join(words, " ")
+}
+
+// germanHundreds returns the German word of 1 to 999 with "einhundert"
+// for the hundreds and the ones before the tens like in "einundzwanzig".
+func germanHundreds(n int) string {
+	var s string
+	if n >= 100 {
+		s = germanInflected(deOnes[n/100], "ein") + "hundert"
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		s += germanInflected(deOnes[n%10], "ein") + "und" + deTens[n/10]
+	case n >= 20:
+		s += deTens[n/10]
+	case n > 0:
+		s += deOnes[n]
+	}
+	return s
+}
+
+// germanInflected replaces a trailing "eins" by the inflected form used
+// before a following word.
+func germanInflected(s, one string) string {
+	if strings.HasSuffix(s, deOnes[1]) {
+		return strings.TrimSuffix(s, deOnes[1]) + one
+	}
+	return s
+}
+
+// French adds "et" before a trailing one of the tens up to seventy,
+// counts in twenties from sixty on, and pluralizes "cent" and "vingt"
+// only at the end of a number.
+var (
+	frOnes = [...]string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf"}
+	frTens   = [...]string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante"}
+	frScales = [...][2]string{{}, {"mille", "mille"}, {"million", "millions"}, {"milliard", "milliards"},
+		{"billion", "billions"}, {"billiard", "billiards"}, {"trillion", "trillions"},
+		{"trilliard", "trilliards"}, {"quadrillion", "quadrillions"}, {"quadrilliard", "quadrilliards"},
+		{"quintillion", "quintillions"}, {"quintilliard", "quintilliards"}}
+)
+
+// frenchCardinal returns the French words of the number, e.g. "deux
+// millions quatre-vingt mille deux cents". Feminine units change a
+// trailing "un" to "une".
+func frenchCardinal(groups []int, feminine bool) string {
+	if isZeroGroups(groups) {
+		return frOnes[0]
+	}
+	var words []string
+	for i := len(groups) - 1; i >= 1; i-- {
+		switch {
+		case groups[i] == 0:
+		case i == 1 && groups[i] == 1:
+			words = append(words, frScales[1][0])
+		case i == 1:
+			words = append(words, frenchHundreds(groups[i], false), frScales[1][0])
+		case groups[i] == 1:
+			words = append(words, frOnes[1], frScales[i][0])
+		default:
+			words = append(words, frenchHundreds(groups[i], true), frScales[i][1])
+		}
+	}
+	if groups[0] > 0 {
+		low := frenchHundreds(groups[0], true)
+		if feminine && strings.HasSuffix(low, frOnes[1]) {
+			low += "e"
+		}
+		words = append(words, low)
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchHundreds returns the French words of 1 to 999. Final is false
+// before "mille", which keeps "cent" and "vingt" in the singular.
+func frenchHundreds(n int, final bool) string {
+	var words []string
+	h, r := n/100, n%100
+	switch {
+	case h == 1:
+		words = append(words, "cent")
+	case h > 1 && r == 0 && final:
+		words = append(words, frOnes[h], "cents")
+	case h > 1:
+		words = append(words, frOnes[h], "cent")
+	}
+	switch {
+	case r == 0:
+	case r < 20:
+		words = append(words, frOnes[r])
+	case r < 70:
+		switch r % 10 {
+		case 0:
+			words = append(words, frTens[r/10])
+		case 1:
+			words = append(words, frTens[r/10]+" et un")
+		default:
+			words = append(words, frTens[r/10]+"-"+frOnes[r%10])
+		}
+	case r == 71:
+		words = append(words, "soixante et onze")
+	case r < 80:
+		words = append(words, "soixante-"+frOnes[r-60])
+	case r == 80 && final:
+		words = append(words, "quatre-vingts")
+	case r == 80:
+		words = append(words, "quatre-vingt")
+	default:
+		words = append(words, "quatre-vingt-"+frOnes[r-80])
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchOf returns "de " or "d'" for numbers ending with millions or
+// higher like in "deux millions de dollars" and "un million d'euros".
+func frenchOf(groups []int, unit string) string {
+	if !isMillionsOnly(groups) {
+		return ""
+	}
+	if strings.ContainsRune("aeiouéh", []rune(unit)[0]) {
+		return "d'"
+	}
+	return "de "
+}
+
+// Spanish uses the long scale with "mil millones" for the milliard, so
+// the scale names come in steps of six digits. Before nouns "uno" becomes
+// "un" or "una", and the hundreds agree with feminine units.
+var (
+	esOnes = [...]string{"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis",
+		"veintisiete", "veintiocho", "veintinueve"}
+	esTens     = [...]string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	esHundreds = [...]string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos",
+		"seiscientos", "setecientos", "ochocientos", "novecientos"}
+	esScales = [...][2]string{{}, {"millón", "millones"}, {"billón", "billones"}, {"trillón", "trillones"},
+		{"cuatrillón", "cuatrillones"}, {"quintillón", "quintillones"}}
+)
+
+// spanishCardinal returns the Spanish words of the number, e.g. "dos
+// millones veintiún mil doscientos".
+func spanishCardinal(groups []int, feminine bool) string {
+	if isZeroGroups(groups) {
+		return esOnes[0]
+	}
+	var words []string
+	for j := (len(groups)+1)/2 - 1; j >= 0; j-- {
+		chunk := groups[2*j]
+		if 2*j+1 < len(groups) {
+			chunk += groups[2*j+1] * 1000
+		}
+		switch {
+		case chunk == 0:
+		case j == 0:
+			words = append(words, spanishThousands(chunk, feminine))
+		case chunk == 1:
+			words = append(words, "un", esScales[j][0])
+		default:
+			words = append(words, spanishThousands(chunk, false), esScales[j][1])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishThousands returns the Spanish words of 1 to 999999.
+func spanishThousands(n int, feminine bool) string {
+	var words []string
+	thousands, rest := n/1000, n%1000
+	switch {
+	case thousands == 1:
+		words = append(words, "mil")
+	case thousands > 1:
+		words = append(words, spanishHundreds(thousands, feminine), "mil")
+	}
+	if rest > 0 {
+		words = append(words, spanishHundreds(rest, feminine))
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishHundreds returns the Spanish words of 1 to 999 as used before
+// a noun.
+func spanishHundreds(n int, feminine bool) string {
+	var words []string
+	h, r := n/100, n%100
+	switch {
+	case h == 1 && r == 0:
+		words = append(words, "cien")
+	case h > 1 && feminine:
+		words = append(words, strings.Replace(esHundreds[h], "ientos", "ientas", 1))
+	case h > 0:
+		words = append(words, esHundreds[h])
+	}
+	one := "un"
+	if feminine {
+		one = "una"
+	}
+	switch {
+	case r == 0:
+	case r == 1:
+		words = append(words, one)
+	case r == 21 && feminine:
+		words = append(words, "veintiuna")
+	case r == 21:
+		words = append(words, "veintiún")
+	case r < 30:
+		words = append(words, esOnes[r])
+	case r%10 == 1:
+		words = append(words, esTens[r/10], "y", one)
+	case r%10 == 0:
+		words = append(words, esTens[r/10])
+	default:
+		words = append(words, esTens[r/10], "y", esOnes[r%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishOf returns "de " for numbers ending with millions or higher
+// like in "un millón de euros".
+func spanishOf(groups []int, _ string) string {
+	if isMillionsOnly(groups) {
+		return "de "
+	}
+	return ""
+}
+
+// numberGroups returns the groups of three digits of a non-negative
+// number, least significant first.
+func numberGroups(n int) []int {
+	groups := []int{n % 1000}
+	for n /= 1000; n > 0; n /= 1000 {
+		groups = append(groups, n%1000)
+	}
+	return groups
+}
+
+// isZeroGroups returns true if all groups are zero.
+func isZeroGroups(groups []int) bool {
+	for _, g := range groups {
+		if g != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isOneGroups returns true if the groups form the number one.
+func isOneGroups(groups []int) bool {
+	return groups[0] == 1 && isZeroGroups(groups[1:])
+}
+
+// isMillionsOnly returns true for numbers of at least a million without
+// thousands and ones.
+func isMillionsOnly(groups []int) bool {
+	return len(groups) > 2 && groups[0] == 0 && groups[1] == 0 && !isZeroGroups(groups[2:])
+}
@@ -0,0 +1,132 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+func TestAmountWords(t *testing.T) {
+	tests := []struct {
+		value string
+		code  string
+		lang  string
+		words string
+	}{
+		{"1234.56", "USD", "en", "One thousand two hundred thirty-four dollars and fifty-six cents"},
+		{"1", "USD", "en", "One dollar"},
+		{"0.01", "EUR", "en", "Zero euros and one cent"},
+		{"21.05", "GBP", "en", "Twenty-one pounds and five pence"},
+		{"101001", "CHF", "en", "One hundred one thousand one francs"},
+		{"2000000", "CAD", "en", "Two million dollars"},
+		{"1500", "JPY", "en", "One thousand five hundred yen"},
+		{"-70.71", "CHF", "en", "Minus seventy francs and seventy-one centimes"},
+
+		{"1234.56", "EUR", "de", "eintausendzweihundertvierunddreißig Euro und sechsundfünfzig Cent"},
+		{"1.01", "EUR", "de", "ein Euro und ein Cent"},
+		{"101001", "CHF", "de", "einhunderteintausendein Franken"},
+		{"1000001", "EUR", "de", "eine Million ein Euro"},
+		{"2001.01", "EUR", "de", "zweitausendein Euro und ein Cent"},
+		{"21000", "GBP", "de", "einundzwanzigtausend Pfund"},
+		{"1000000", "USD", "de", "eine Million Dollar"},
+		{"901000002", "EUR", "de", "neunhunderteine Millionen zwei Euro"},
+		{"3000000000", "EUR", "de", "drei Milliarden Euro"},
+
+		{"1234.56", "EUR", "fr", "mille deux cent trente-quatre euros et cinquante-six centimes"},
+		{"0", "EUR", "fr", "zéro euro"},
+		{"71.80", "CHF", "fr", "soixante et onze francs et quatre-vingts centimes"},
+		{"81", "GBP", "fr", "quatre-vingt-une livres"},
+		{"21", "GBP", "fr", "vingt et une livres"},
+		{"280200", "USD", "fr", "deux cent quatre-vingt mille deux cents dollars"},
+		{"1000000", "EUR", "fr", "un million d'euros"},
+		{"2000000", "USD", "fr", "deux millions de dollars"},
+		{"200000000.99", "USD", "fr", "deux cents millions de dollars et quatre-vingt-dix-neuf cents"},
+
+		{"1234.56", "EUR", "es", "mil doscientos treinta y cuatro euros con cincuenta y seis céntimos"},
+		{"1", "USD", "es", "un dólar"},
+		{"21", "USD", "es", "veintiún dólares"},
+		{"21", "GBP", "es", "veintiuna libras"},
+		{"200500", "GBP", "es", "doscientas mil quinientas libras"},
+		{"100.31", "MXN", "es", "cien pesos con treinta y un centavos"},
+		{"1000000", "EUR", "es", "un millón de euros"},
+		{"1000000000", "EUR", "es", "mil millones de euros"},
+		{"2000001000000", "USD", "es", "dos billones un millón de dólares"},
+	}
+	for _, test := range tests {
+		a, err := NewAmount(test.value, test.code)
+		verify.NoError(t, err)
+		words, err := a.Words(test.lang)
+		verify.NoError(t, err)
+		verify.Equal(t, words, test.words, test.value, test.code, test.lang)
+	}
+
+	// Feminine units inflect a trailing one to "eine"
+	verify.Equal(t, germanCardinal([]int{1, 1}, true), "eintausendeine")
+	verify.Equal(t, germanCardinal([]int{1, 0, 2}, true), "zwei Millionen eine")
+}
+
+func TestAmountWordsChequeFraction(t *testing.T) {
+	tests := []struct {
+		value string
+		code  string
+		lang  string
+		words string
+	}{
+		{"1234.56", "USD", "en", "One thousand two hundred thirty-four dollars and 56/100"},
+		{"100", "USD", "en", "One hundred dollars and 00/100"},
+		{"0.05", "EUR", "de", "null Euro und 05/100"},
+		{"12.5", "BTC", "en", "Twelve BTC and 50000000/100000000"},
+		{"1500", "JPY", "fr", "mille cinq cents yens"},
+	}
+	for _, test := range tests {
+		a, err := NewAmount(test.value, test.code)
+		verify.NoError(t, err)
+		words, err := a.Words(test.lang, WithChequeFraction())
+		verify.NoError(t, err)
+		verify.Equal(t, words, test.words, test.value, test.code, test.lang)
+	}
+
+	// Currencies without names always use the fraction.
+	a, err := NewAmount("12.5", "SEK")
+	verify.NoError(t, err)
+	words, err := a.Words("es")
+	verify.NoError(t, err)
+	verify.Equal(t, words, "doce SEK con 50/100")
+}
+
+func TestAmountWordsLimits(t *testing.T) {
+	largest := strings.Repeat("9", WordsMaxDigits)
+	a, err := NewAmount(largest, "USD")
+	verify.NoError(t, err)
+	for _, lang := range []string{"en", "de", "fr", "es"} {
+		_, err = a.Words(lang)
+		verify.NoError(t, err)
+	}
+	words, err := a.Words("en")
+	verify.NoError(t, err)
+	verify.True(t, strings.HasPrefix(words, "Nine hundred ninety-nine decillion"))
+
+	a, err = NewAmount("1"+largest, "USD")
+	verify.NoError(t, err)
+	_, err = a.Words("en")
+	verify.IsError(t, err, ErrOverflow)
+
+	_, err = a.Words("it")
+	verify.IsError(t, err, ErrInvalidOperation)
+}
+
+func BenchmarkAmountWords(b *testing.B) {
+	a, _ := NewAmount("1234567.89", "EUR")
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = a.Words("de")
+	}
+}
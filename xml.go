@@ -0,0 +1,131 @@
+// Tideland Go BCD
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// XMLCurrencyAttr is the default name of the attribute containing the
+// currency code of an amount, e.g. <Amt Ccy="EUR">123.45</Amt>.
+const XMLCurrencyAttr = "Ccy"
+
+// XMLAmount wraps an amount for XML elements of schemas with other
+// settings than the Amount defaults. The settings are kept per field, so
+// different schemas can be used side by side:
+//
+//	type Payment struct {
+//		Amt bcd.XMLAmount `xml:"InstdAmt"`
+//	}
+//
+//	p := Payment{Amt: bcd.XMLAmount{CurrencyAttr: "Currency"}}
+//	err := xml.Unmarshal(data, &p)
+type XMLAmount struct {
+	Amount *Amount
+
+	// CurrencyAttr is the name of the currency attribute, XMLCurrencyAttr
+	// if empty.
+	CurrencyAttr string
+
+	// AllowExcessScale allows unmarshaling amounts with more decimal
+	// places than their currency has. They are rounded half-even then,
+	// otherwise ErrInvalidAmount is returned.
+	AllowExcessScale bool
+}
+
+// MarshalXML implements xml.Marshaler, the value is written as character
+// data of the element.
+func (b *BCD) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(b.String(), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, reading the value from the
+// character data of the element.
+func (b *BCD) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// MarshalXML implements xml.Marshaler. The amount is written with the
+// decimal places of its currency as character data, the currency code
+// as attribute named XMLCurrencyAttr.
+func (c *Amount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return XMLAmount{Amount: c}.MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. It reads the currency code
+// from the attribute named XMLCurrencyAttr and the amount from the
+// character data. Amounts with more decimal places than the currency
+// are rejected, XMLAmount allows them.
+func (c *Amount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var x XMLAmount
+	if err := x.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	*c = *x.Amount
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler like Amount.MarshalXML with the
+// currency attribute of the settings.
+func (x XMLAmount) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if x.Amount == nil || x.Amount.amount == nil {
+		return ErrInvalidAmount
+	}
+	start.Attr = append(start.Attr, xml.Attr{
+		Name:  xml.Name{Local: x.currencyAttr()},
+		Value: x.Amount.info.Code,
+	})
+	return e.EncodeElement(string(x.Amount.AppendFormat(nil, false, false)), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler like Amount.UnmarshalXML with
+// the settings of x.
+func (x *XMLAmount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	attrName := x.currencyAttr()
+	var code string
+	for _, attr := range start.Attr {
+		if attr.Name.Local == attrName {
+			code = attr.Value
+		}
+	}
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if code == "" {
+		return fmt.Errorf("%w: missing currency attribute %s", ErrInvalidAmount, attrName)
+	}
+
+	value, err := parseString(s)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	a, err := NewAmount(value, code)
+	if err != nil {
+		return err
+	}
+	if value.scale > a.info.DecimalPlaces && !x.AllowExcessScale {
+		return fmt.Errorf("%w: %s has more than %d decimal places of %s",
+			ErrInvalidAmount, value, a.info.DecimalPlaces, a.info.Code)
+	}
+	x.Amount = a
+	return nil
+}
+
+// currencyAttr returns the name of the currency attribute.
+func (x *XMLAmount) currencyAttr() string {
+	if x.CurrencyAttr == "" {
+		return XMLCurrencyAttr
+	}
+	return x.CurrencyAttr
+}
@@ -0,0 +1,105 @@
+// Tideland Go BCD - Unit Tests
+//
+// Copyright (C) 2025 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package bcd
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+)
+
+// payment is a partner document with amounts and a rate.
+type payment struct {
+	XMLName xml.Name `xml:"Pmt"`
+	Amt     *Amount  `xml:"Amt"`
+	Fee     *Amount  `xml:"Fee,omitempty"`
+	Rate    *BCD     `xml:"Rate"`
+	Limit   *BCD     `xml:"limit,attr,omitempty"`
+}
+
+func TestXMLMarshal(t *testing.T) {
+	p := payment{
+		Amt:   MustNewAmount("123.4", "EUR"),
+		Rate:  Must("1.0825"),
+		Limit: Must(500),
+	}
+	data, err := xml.Marshal(p)
+	verify.NoError(t, err)
+	verify.Equal(t, string(data), `<Pmt limit="500"><Amt Ccy="EUR">123.40</Amt><Rate>1.0825</Rate></Pmt>`)
+
+	var q payment
+	verify.NoError(t, xml.Unmarshal(data, &q))
+	verify.True(t, q.Amt.Equal(p.Amt))
+	verify.True(t, q.Rate.Identical(p.Rate))
+	verify.Equal(t, q.Limit.String(), "500")
+	verify.Equal(t, q.Fee == nil, true)
+}
+
+func TestXMLUnmarshalAmount(t *testing.T) {
+	tests := []struct {
+		xml      string
+		expected string
+		err      error
+	}{
+		{`<Amt Ccy="EUR">123.45</Amt>`, "123.45 EUR", nil},
+		{`<Amt Ccy="JPY"> 1500 </Amt>`, "1500 JPY", nil},
+		{`<Amt Ccy="EUR">123.450</Amt>`, "123.45 EUR", nil},
+		{`<Amt Ccy="eur">-0.5</Amt>`, "-0.50 EUR", nil},
+		{`<Amt Ccy="EUR">123.456</Amt>`, "", ErrInvalidAmount},
+		{`<Amt Ccy="JPY">10.5</Amt>`, "", ErrInvalidAmount},
+		{`<Amt>1.00</Amt>`, "", ErrInvalidAmount},
+		{`<Amt Ccy="EUR">abc</Amt>`, "", ErrInvalidAmount},
+		{`<Amt Ccy="EUR">NaN</Amt>`, "", ErrInvalidAmount},
+		{`<Amt Ccy="XYZ">1</Amt>`, "", ErrUnknownCurrency},
+	}
+	for _, test := range tests {
+		var a Amount
+		err := xml.Unmarshal([]byte(test.xml), &a)
+		if test.err != nil {
+			verify.IsError(t, err, test.err)
+			continue
+		}
+		verify.NoError(t, err)
+		verify.Equal(t, a.Format(false, true), test.expected)
+	}
+
+	// Invalid values keep the cause
+	var a Amount
+	verify.IsError(t, xml.Unmarshal([]byte(`<Amt Ccy="EUR">abc</Amt>`), &a), ErrInvalidFormat)
+}
+
+func TestXMLAmount(t *testing.T) {
+	type payment struct {
+		XMLName xml.Name  `xml:"CdtTrfTxInf"`
+		Amt     XMLAmount `xml:"InstdAmt"`
+		Fee     *Amount   `xml:"Fee"`
+	}
+	data, err := xml.Marshal(payment{
+		Amt: XMLAmount{Amount: MustNewAmount(10, "CHF"), CurrencyAttr: "Currency"},
+		Fee: MustNewAmount("0.5", "CHF"),
+	})
+	verify.NoError(t, err)
+	verify.Equal(t, string(data), `<CdtTrfTxInf><InstdAmt Currency="CHF">10.00</InstdAmt><Fee Ccy="CHF">0.50</Fee></CdtTrfTxInf>`)
+
+	p := payment{Amt: XMLAmount{CurrencyAttr: "Currency"}}
+	verify.NoError(t, xml.Unmarshal(data, &p))
+	verify.Equal(t, p.Amt.Amount.Format(false, true), "10.00 CHF")
+	verify.Equal(t, p.Fee.Format(false, true), "0.50 CHF")
+
+	// Other attribute and excess scale only with the settings
+	var a Amount
+	verify.IsError(t, xml.Unmarshal([]byte(`<Amt Currency="EUR">1</Amt>`), &a), ErrInvalidAmount)
+	verify.IsError(t, xml.Unmarshal([]byte(`<Amt Ccy="EUR">0.125</Amt>`), &a), ErrInvalidAmount)
+
+	x := XMLAmount{CurrencyAttr: "Currency"}
+	verify.IsError(t, xml.Unmarshal([]byte(`<Amt Ccy="EUR">1</Amt>`), &x), ErrInvalidAmount)
+	x.AllowExcessScale = true
+	verify.NoError(t, xml.Unmarshal([]byte(`<Amt Currency="EUR">0.125</Amt>`), &x))
+	verify.Equal(t, x.Amount.Format(false, true), "0.12 EUR")
+}